@@ -0,0 +1,155 @@
+package interchaintest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/math"
+
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+)
+
+// BalanceKey identifies the balance of a single denom for an address on a chain.
+// For IBC vouchers, the denom can be computed with ibc.IBCDenom.
+type BalanceKey struct {
+	Chain   ibc.Chain
+	Address string
+	Denom   string
+}
+
+func (k BalanceKey) String() string {
+	return fmt.Sprintf("%s %s on %s", k.Address, k.Denom, k.Chain.Config().ChainID)
+}
+
+// BalanceSnapshot holds balances recorded by TakeBalanceSnapshot,
+// to compare against the balances at a later point of a test.
+type BalanceSnapshot struct {
+	keys     []BalanceKey
+	balances map[BalanceKey]math.Int
+
+	// Height of each chain immediately before its balances were recorded.
+	heights map[ibc.Chain]int64
+}
+
+// TakeBalanceSnapshot records the current balance for each of the given keys.
+func TakeBalanceSnapshot(ctx context.Context, keys ...BalanceKey) (*BalanceSnapshot, error) {
+	s := &BalanceSnapshot{
+		balances: make(map[BalanceKey]math.Int, len(keys)),
+		heights:  make(map[ibc.Chain]int64),
+	}
+
+	for _, k := range keys {
+		if _, ok := s.balances[k]; ok {
+			continue
+		}
+
+		if _, ok := s.heights[k.Chain]; !ok {
+			h, err := k.Chain.Height(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get height of %s: %w", k.Chain.Config().ChainID, err)
+			}
+			s.heights[k.Chain] = h
+		}
+
+		bal, err := k.Chain.GetBalance(ctx, k.Address, k.Denom)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get balance of %s: %w", k, err)
+		}
+		s.balances[k] = bal
+		s.keys = append(s.keys, k)
+	}
+
+	return s, nil
+}
+
+// Height returns the height of chain when the snapshot was taken, or 0 if the snapshot has no balance on chain.
+func (s *BalanceSnapshot) Height(chain ibc.Chain) int64 {
+	return s.heights[chain]
+}
+
+// Balance returns the recorded balance for key, or false if it was not recorded.
+func (s *BalanceSnapshot) Balance(key BalanceKey) (math.Int, bool) {
+	bal, ok := s.balances[key]
+	return bal, ok
+}
+
+// Deltas returns the change of every recorded balance since the snapshot was taken.
+func (s *BalanceSnapshot) Deltas(ctx context.Context) (map[BalanceKey]math.Int, error) {
+	deltas := make(map[BalanceKey]math.Int, len(s.keys))
+	for _, k := range s.keys {
+		bal, err := k.Chain.GetBalance(ctx, k.Address, k.Denom)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get balance of %s: %w", k, err)
+		}
+		deltas[k] = bal.Sub(s.balances[k])
+	}
+	return deltas, nil
+}
+
+// BalanceDelta is an expected change of a recorded balance.
+type BalanceDelta struct {
+	BalanceKey
+
+	// Amount is the expected change, excluding gas fees. Negative for funds leaving the address.
+	// A nil Amount is treated as zero.
+	Amount math.Int
+
+	// GasSpent is the total gas paid by Address, e.g. the sum of ibc.Tx.GasSpent of its transactions.
+	// If Denom is the native denom of Chain, the fees for this gas,
+	// as computed by ibc.Chain.GetGasFeesInNativeDenom, are also expected to be deducted.
+	GasSpent int64
+}
+
+// expected returns the expected total change of the balance.
+func (d BalanceDelta) expected() math.Int {
+	amount := d.Amount
+	if amount.IsNil() {
+		amount = math.ZeroInt()
+	}
+	if d.GasSpent != 0 && d.Denom == d.Chain.Config().Denom {
+		amount = amount.SubRaw(d.Chain.GetGasFeesInNativeDenom(d.GasSpent))
+	}
+	return amount
+}
+
+// CheckDeltas compares the current balances against the snapshot,
+// and returns an error listing every balance whose change differs from the expected delta.
+// Recorded balances without an expected delta must not have changed.
+// Expected deltas must refer to recorded balances.
+func (s *BalanceSnapshot) CheckDeltas(ctx context.Context, expected ...BalanceDelta) error {
+	want := make(map[BalanceKey]math.Int, len(s.keys))
+	for _, k := range s.keys {
+		want[k] = math.ZeroInt()
+	}
+	for _, d := range expected {
+		if _, ok := s.balances[d.BalanceKey]; !ok {
+			return fmt.Errorf("balance of %s was not recorded in the snapshot", d.BalanceKey)
+		}
+		want[d.BalanceKey] = want[d.BalanceKey].Add(d.expected())
+	}
+
+	deltas, err := s.Deltas(ctx)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, k := range s.keys {
+		if !deltas[k].Equal(want[k]) {
+			errs = append(errs, fmt.Errorf("balance of %s changed by %s, expected %s", k, deltas[k], want[k]))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// RequireDeltas fails the test if CheckDeltas returns an error.
+// t is typically a *testing.T, or the TestifyT of a testreporter.Reporter to report the failure.
+func (s *BalanceSnapshot) RequireDeltas(ctx context.Context, t require.TestingT, expected ...BalanceDelta) {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	require.NoError(t, s.CheckDeltas(ctx, expected...))
+}
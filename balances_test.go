@@ -0,0 +1,86 @@
+package interchaintest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/math"
+
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+)
+
+// balanceChain serves balances from a map, and charges 2 native units per gas.
+type balanceChain struct {
+	ibc.Chain
+
+	balances map[string]math.Int
+}
+
+func (c *balanceChain) Config() ibc.ChainConfig {
+	return ibc.ChainConfig{ChainID: "chain-1", Denom: "ustake"}
+}
+
+func (c *balanceChain) Height(context.Context) (int64, error) {
+	return 10, nil
+}
+
+func (c *balanceChain) GetBalance(_ context.Context, address, denom string) (math.Int, error) {
+	if bal, ok := c.balances[address+"/"+denom]; ok {
+		return bal, nil
+	}
+	return math.ZeroInt(), nil
+}
+
+func (c *balanceChain) GetGasFeesInNativeDenom(gasPaid int64) int64 {
+	return 2 * gasPaid
+}
+
+func TestBalanceSnapshot(t *testing.T) {
+	ctx := context.Background()
+
+	ibcDenom := ibc.IBCDenom("uatom", ibc.ChannelOutput{
+		PortID: "transfer", ChannelID: "channel-0",
+		Counterparty: ibc.ChannelCounterparty{PortID: "transfer", ChannelID: "channel-1"},
+	})
+
+	chain := &balanceChain{balances: map[string]math.Int{
+		"alice/ustake":      math.NewInt(1000),
+		"alice/" + ibcDenom: math.NewInt(0),
+	}}
+	native := BalanceKey{Chain: chain, Address: "alice", Denom: "ustake"}
+	voucher := BalanceKey{Chain: chain, Address: "alice", Denom: ibcDenom}
+	bob := BalanceKey{Chain: chain, Address: "bob", Denom: "ustake"}
+
+	s, err := TakeBalanceSnapshot(ctx, native, voucher, bob, native)
+	require.NoError(t, err)
+	require.Equal(t, int64(10), s.Height(chain))
+
+	bal, ok := s.Balance(native)
+	require.True(t, ok)
+	require.Equal(t, math.NewInt(1000), bal)
+
+	// Alice sends 100 to Bob paying 5 gas, and receives 50 vouchers.
+	chain.balances["alice/ustake"] = math.NewInt(890)
+	chain.balances["bob/ustake"] = math.NewInt(100)
+	chain.balances["alice/"+ibcDenom] = math.NewInt(50)
+
+	s.RequireDeltas(ctx, t,
+		BalanceDelta{BalanceKey: native, Amount: math.NewInt(-100), GasSpent: 5},
+		BalanceDelta{BalanceKey: voucher, Amount: math.NewInt(50)},
+		BalanceDelta{BalanceKey: bob, Amount: math.NewInt(100)},
+	)
+
+	// Gas is not charged in the voucher denom, and unexpected changes are reported.
+	err = s.CheckDeltas(ctx,
+		BalanceDelta{BalanceKey: native, Amount: math.NewInt(-100)},
+		BalanceDelta{BalanceKey: voucher, Amount: math.NewInt(50), GasSpent: 5},
+	)
+	require.ErrorContains(t, err, "balance of alice ustake on chain-1 changed by -110, expected -100")
+	require.ErrorContains(t, err, "balance of bob ustake on chain-1 changed by 100, expected 0")
+	require.NotContains(t, err.Error(), ibcDenom)
+
+	err = s.CheckDeltas(ctx, BalanceDelta{BalanceKey: BalanceKey{Chain: chain, Address: "carol", Denom: "ustake"}})
+	require.ErrorContains(t, err, "was not recorded")
+}
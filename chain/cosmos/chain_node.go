@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"hash/fnv"
+	"io"
 	"math/rand"
 	"os"
 	"path"
@@ -28,7 +29,11 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 
 	icatypes "github.com/cosmos/ibc-go/v8/modules/apps/27-interchain-accounts/types"
+	transfertypes "github.com/cosmos/ibc-go/v8/modules/apps/transfer/types"
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+	ibcexported "github.com/cosmos/ibc-go/v8/modules/core/exported"
 	ccvclient "github.com/cosmos/interchain-security/v5/x/ccv/provider/client"
+	providertypes "github.com/cosmos/interchain-security/v5/x/ccv/provider/types"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/codec"
@@ -47,6 +52,7 @@ import (
 	libclient "github.com/cometbft/cometbft/rpc/jsonrpc/client"
 
 	"github.com/strangelove-ventures/interchaintest/v8/blockdb"
+	"github.com/strangelove-ventures/interchaintest/v8/chain/internal/ibcquery"
 	"github.com/strangelove-ventures/interchaintest/v8/dockerutil"
 	"github.com/strangelove-ventures/interchaintest/v8/ibc"
 	"github.com/strangelove-ventures/interchaintest/v8/testutil"
@@ -419,6 +425,47 @@ func (tn *ChainNode) SetPeers(ctx context.Context, peers string) error {
 	)
 }
 
+// SetHaltHeight modifies the app config halt-height for a node, which stops committing blocks at that height.
+// A height of 0 disables the halt. The node must be restarted for the change to take effect.
+func (tn *ChainNode) SetHaltHeight(ctx context.Context, height int64) error {
+	a := make(testutil.Toml)
+	a["halt-height"] = height
+
+	return testutil.ModifyTomlConfigFile(
+		ctx,
+		tn.logger(),
+		tn.DockerClient,
+		tn.TestName,
+		tn.VolumeName,
+		"config/app.toml",
+		a,
+	)
+}
+
+// SetStateSyncConfig modifies the config statesync for a node, so that it bootstraps from a snapshot
+// served by its peers, verified by light blocks of the rpcServers trusting the block trustHeight with hash trustHash.
+func (tn *ChainNode) SetStateSyncConfig(ctx context.Context, rpcServers []string, trustHeight int64, trustHash string, trustPeriod time.Duration) error {
+	c := make(testutil.Toml)
+	statesync := make(testutil.Toml)
+
+	statesync["enable"] = true
+	statesync["rpc_servers"] = strings.Join(rpcServers, ",")
+	statesync["trust_height"] = trustHeight
+	statesync["trust_hash"] = trustHash
+	statesync["trust_period"] = trustPeriod.String()
+	c["statesync"] = statesync
+
+	return testutil.ModifyTomlConfigFile(
+		ctx,
+		tn.logger(),
+		tn.DockerClient,
+		tn.TestName,
+		tn.VolumeName,
+		"config/config.toml",
+		c,
+	)
+}
+
 func (tn *ChainNode) Height(ctx context.Context) (int64, error) {
 	res, err := tn.Client.Status(ctx)
 	if err != nil {
@@ -578,7 +625,12 @@ func (tn *ChainNode) ExecTx(ctx context.Context, keyName string, command ...stri
 }
 
 // TxHashToResponse returns the sdk transaction response struct for a given transaction hash.
+// If the chain uses ibc.TxModeGRPC, the transaction is queried over RPC instead of running the chain binary.
 func (tn *ChainNode) TxHashToResponse(ctx context.Context, txHash string) (*sdk.TxResponse, error) {
+	if tn.UsesGRPCTx() {
+		return tn.GetTransaction(tn.CliContext(), txHash)
+	}
+
 	stdout, stderr, err := tn.ExecQuery(ctx, "tx", txHash)
 	if err != nil {
 		tn.log.Info("TxHashToResponse returned an error",
@@ -720,11 +772,31 @@ func (tn *ChainNode) ReadFile(ctx context.Context, relPath string) ([]byte, erro
 	return gen, nil
 }
 
+// ExportVolume writes the node's entire home directory to w as a tar stream.
+// The node container should be stopped first so that the archive is consistent.
+func (tn *ChainNode) ExportVolume(ctx context.Context, w io.Writer) error {
+	va := dockerutil.NewVolumeArchiver(tn.logger(), tn.DockerClient, tn.TestName)
+	return va.Export(ctx, tn.VolumeName, w)
+}
+
+// ImportVolume extracts a tar stream produced by ExportVolume into the node's home directory,
+// applying rewrite to the content of every file if it is non-nil.
+func (tn *ChainNode) ImportVolume(ctx context.Context, r io.Reader, rewrite dockerutil.RewriteFunc) error {
+	va := dockerutil.NewVolumeArchiver(tn.logger(), tn.DockerClient, tn.TestName)
+	return va.Import(ctx, tn.VolumeName, r, rewrite)
+}
+
 // CreateKey creates a key in the keyring backend test for the given node.
+// If the chain uses ibc.TxModeGRPC, the key is created in Go, in the keyring of the chain, without running the chain binary.
 func (tn *ChainNode) CreateKey(ctx context.Context, name string) error {
 	tn.lock.Lock()
 	defer tn.lock.Unlock()
 
+	if tn.UsesGRPCTx() {
+		return tn.createKey(ctx, tn.keyUID(name), name, "")
+	}
+	tn.forgetKey(name)
+
 	_, _, err := tn.ExecBin(ctx,
 		"keys", "add", name,
 		"--coin-type", tn.Chain.Config().CoinType,
@@ -734,7 +806,15 @@ func (tn *ChainNode) CreateKey(ctx context.Context, name string) error {
 }
 
 // RecoverKey restores a key from a given mnemonic.
+// If the chain uses ibc.TxModeGRPC, the key is restored in Go, in the keyring of the chain, without running the chain binary.
 func (tn *ChainNode) RecoverKey(ctx context.Context, keyName, mnemonic string) error {
+	if tn.UsesGRPCTx() {
+		tn.lock.Lock()
+		defer tn.lock.Unlock()
+
+		return tn.createKey(ctx, tn.keyUID(keyName), keyName, mnemonic)
+	}
+
 	command := []string{
 		"sh",
 		"-c",
@@ -744,6 +824,8 @@ func (tn *ChainNode) RecoverKey(ctx context.Context, keyName, mnemonic string) e
 	tn.lock.Lock()
 	defer tn.lock.Unlock()
 
+	tn.forgetKey(keyName)
+
 	_, _, err := tn.Exec(ctx, command, tn.Chain.Config().Env)
 	return err
 }
@@ -861,6 +943,9 @@ func (tn *ChainNode) SendIBCTransfer(
 	if options.Port != "" {
 		port = options.Port
 	}
+	if tn.UsesGRPCTx() {
+		return tn.broadcastIBCTransfer(ctx, port, channelID, keyName, amount, options)
+	}
 	command := []string{
 		"ibc-transfer", "transfer", port, channelID,
 		amount.Address, fmt.Sprintf("%s%s", amount.Amount.String(), amount.Denom),
@@ -887,7 +972,80 @@ func (tn *ChainNode) SendIBCTransfer(
 	return tn.ExecTx(ctx, keyName, command...)
 }
 
+// broadcastIBCTransfer is the gRPC counterpart of the ibc-transfer transfer command run by SendIBCTransfer.
+// Timeouts are the same as the command's: relative to the latest height and timestamp of the counterparty client
+// unless options.AbsoluteTimeouts is set, and defaulting to the command's defaults when options.Timeout leaves them unset.
+func (tn *ChainNode) broadcastIBCTransfer(
+	ctx context.Context,
+	port, channelID, keyName string,
+	amount ibc.WalletAmount,
+	options ibc.TransferOptions,
+) (string, error) {
+	timeoutHeight, err := clienttypes.ParseHeight(transfertypes.DefaultRelativePacketTimeoutHeight)
+	if err != nil {
+		return "", err
+	}
+	timeoutTimestamp := transfertypes.DefaultRelativePacketTimeoutTimestamp
+	if options.Timeout != nil {
+		if options.Timeout.NanoSeconds > 0 {
+			timeoutTimestamp = options.Timeout.NanoSeconds
+		}
+		if options.Timeout.Height > 0 {
+			timeoutHeight = clienttypes.NewHeight(0, uint64(options.Timeout.Height))
+		}
+	}
+
+	if options.Timeout == nil || !options.AbsoluteTimeouts {
+		q := ibcquery.New(tn.GrpcConn, tn.Chain.Config().EncodingConfig.InterfaceRegistry)
+		clientID, clientState, err := q.ChannelClientState(ctx, port, channelID)
+		if err != nil {
+			return "", fmt.Errorf("failed to query client of channel %s/%s: %w", port, channelID, err)
+		}
+		clientHeight, ok := clientState.GetLatestHeight().(clienttypes.Height)
+		if !ok {
+			return "", fmt.Errorf("invalid height type %T", clientState.GetLatestHeight())
+		}
+		timeoutHeight = clienttypes.NewHeight(
+			clientHeight.RevisionNumber+timeoutHeight.RevisionNumber,
+			clientHeight.RevisionHeight+timeoutHeight.RevisionHeight,
+		)
+
+		reference := uint64(time.Now().UnixNano())
+		if clientState.ClientType() != ibcexported.Localhost {
+			consensusState, err := q.QueryConsensusState(ctx, clientID, clientHeight)
+			if err != nil {
+				return "", fmt.Errorf("failed to query consensus state of client %s: %w", clientID, err)
+			}
+			reference = max(reference, consensusState.GetTimestamp())
+		}
+		timeoutTimestamp += reference
+	}
+
+	sender, err := tn.KeyAddress(ctx, keyName)
+	if err != nil {
+		return "", err
+	}
+
+	msg := transfertypes.NewMsgTransfer(
+		port, channelID, sdk.NewCoin(amount.Denom, amount.Amount), sender, amount.Address,
+		timeoutHeight, timeoutTimestamp, options.Memo,
+	)
+	return tn.BroadcastMsgs(ctx, keyName, msg)
+}
+
 func (tn *ChainNode) ConsumerAdditionProposal(ctx context.Context, keyName string, prop ccvclient.ConsumerAdditionProposalJSON) (string, error) {
+	if tn.UsesGRPCTx() {
+		content := providertypes.NewConsumerAdditionProposal(
+			prop.Title, prop.Summary, prop.ChainId, prop.InitialHeight,
+			prop.GenesisHash, prop.BinaryHash, prop.SpawnTime,
+			prop.ConsumerRedistributionFraction, prop.BlocksPerDistributionTransmission,
+			prop.DistributionTransmissionChannel, prop.HistoricalEntries,
+			prop.CcvTimeoutPeriod, prop.TransferTimeoutPeriod, prop.UnbondingPeriod, prop.TopN,
+			prop.ValidatorsPowerCap, prop.ValidatorSetCap, prop.Allowlist, prop.Denylist,
+		)
+		return tn.broadcastLegacyProposal(ctx, keyName, content, prop.Deposit)
+	}
+
 	propBz, err := json.Marshal(prop)
 	if err != nil {
 		return "", err
@@ -1128,7 +1286,11 @@ func (tn *ChainNode) CreateNodeContainer(ctx context.Context) error {
 		cmd = append(cmd, "--with-tendermint=false", fmt.Sprintf("--transport=%s", connectionMode), fmt.Sprintf("--address=%s", abciAppAddr))
 
 		blockTime := chainCfg.CometMock.BlockTimeMs
-		if blockTime <= 0 {
+		switch {
+		case chainCfg.CometMock.ManualBlocks:
+			// A negative block time disables automatic block production.
+			blockTime = -1
+		case blockTime <= 0:
 			blockTime = 100
 		}
 		blockTimeFlag := fmt.Sprintf("--block-time=%d", blockTime)
@@ -1264,6 +1426,11 @@ func (tn *ChainNode) StartContainer(ctx context.Context) error {
 	}, retry.Context(ctx), retry.Attempts(40), retry.Delay(3*time.Second), retry.DelayType(retry.FixedDelay))
 }
 
+// Logs returns the logs of the node container, limited to the last tail lines unless tail is 0.
+func (tn *ChainNode) Logs(ctx context.Context, tail uint64) (string, error) {
+	return tn.containerLifecycle.Logs(ctx, tail)
+}
+
 func (tn *ChainNode) PauseContainer(ctx context.Context) error {
 	for _, s := range tn.Sidecars {
 		if err := s.PauseContainer(ctx); err != nil {
@@ -1348,7 +1515,26 @@ func (tn *ChainNode) NodeID(ctx context.Context) (string, error) {
 
 // KeyBech32 retrieves the named key's address in bech32 format from the node.
 // bech is the bech32 prefix (acc|val|cons). If empty, defaults to the account key (same as "acc").
+// If the chain uses ibc.TxModeGRPC, the address is read from the keyring of the chain instead of running the chain binary.
 func (tn *ChainNode) KeyBech32(ctx context.Context, name string, bech string) (string, error) {
+	if tn.UsesGRPCTx() {
+		_, addr, err := tn.keyAddress(ctx, name)
+		if err != nil {
+			return "", fmt.Errorf("failed to show key %q: %w", name, err)
+		}
+		prefix := tn.Chain.Config().Bech32Prefix
+		switch bech {
+		case "", "acc":
+		case "val":
+			prefix += sdk.PrefixValidator + sdk.PrefixOperator
+		case "cons":
+			prefix += sdk.PrefixValidator + sdk.PrefixConsensus
+		default:
+			return "", fmt.Errorf("invalid bech32 prefix %q", bech)
+		}
+		return sdk.Bech32ifyAddressBytes(prefix, addr)
+	}
+
 	command := []string{
 		tn.Chain.Config().Bin, "keys", "show", "--address", name,
 		"--home", tn.HomeDir(),
@@ -1432,13 +1618,18 @@ func (tn *ChainNode) logger() *zap.Logger {
 }
 
 // RegisterICA will attempt to register an interchain account on the counterparty chain.
+// Deprecated: use ICARegister instead.
 func (tn *ChainNode) RegisterICA(ctx context.Context, keyName, connectionID string) (string, error) {
+	if tn.UsesGRPCTx() {
+		return tn.ICARegister(ctx, keyName, connectionID, ICARegisterOptions{})
+	}
 	return tn.ExecTx(ctx, keyName,
 		"interchain-accounts", "controller", "register", connectionID,
 	)
 }
 
 // QueryICA will query for an interchain account controlled by the specified address on the counterparty chain.
+// Deprecated: use CosmosChain.ICAQueryAddress instead.
 func (tn *ChainNode) QueryICA(ctx context.Context, connectionID, address string) (string, error) {
 	stdout, _, err := tn.ExecQuery(ctx,
 		"interchain-accounts", "controller", "interchain-account", address, connectionID,
@@ -1459,6 +1650,7 @@ func (tn *ChainNode) QueryICA(ctx context.Context, connectionID, address string)
 
 // SendICATx sends an interchain account transaction for a specified address and sends it to the specified
 // interchain account.
+// Deprecated: use ICASendTx with NewICAPacketData instead.
 func (tn *ChainNode) SendICATx(ctx context.Context, keyName, connectionID string, registry codectypes.InterfaceRegistry, msgs []sdk.Msg, icaTxMemo string, encoding string) (string, error) {
 	cdc := codec.NewProtoCodec(registry)
 	icaPacketDataBytes, err := icatypes.SerializeCosmosTx(cdc, msgs, encoding)
@@ -1476,6 +1668,10 @@ func (tn *ChainNode) SendICATx(ctx context.Context, keyName, connectionID string
 		return "", err
 	}
 
+	if tn.UsesGRPCTx() {
+		return tn.ICASendTx(ctx, keyName, connectionID, icaPacketData, 0)
+	}
+
 	icaPacketBytes, err := cdc.MarshalJSON(&icaPacketData)
 	if err != nil {
 		return "", err
@@ -1486,6 +1682,7 @@ func (tn *ChainNode) SendICATx(ctx context.Context, keyName, connectionID string
 
 // SendICABankTransfer builds a bank transfer message for a specified address and sends it to the specified
 // interchain account.
+// Deprecated: use CosmosChain.ICAExecute instead.
 func (tn *ChainNode) SendICABankTransfer(ctx context.Context, connectionID, fromAddr string, amount ibc.WalletAmount) error {
 	fromAddress := sdk.MustAccAddressFromBech32(fromAddr)
 	toAddress := sdk.MustAccAddressFromBech32(amount.Address)
@@ -1,9 +1,12 @@
 package cosmos
 
 import (
+	"cosmossdk.io/x/circuit"
+	nftmodule "cosmossdk.io/x/nft/module"
 	"cosmossdk.io/x/upgrade"
 
 	"github.com/cosmos/ibc-go/modules/capability"
+	ica "github.com/cosmos/ibc-go/v8/modules/apps/27-interchain-accounts"
 	transfer "github.com/cosmos/ibc-go/v8/modules/apps/transfer"
 	ibccore "github.com/cosmos/ibc-go/v8/modules/core"
 	ibctm "github.com/cosmos/ibc-go/v8/modules/light-clients/07-tendermint"
@@ -12,9 +15,11 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
 	"github.com/cosmos/cosmos-sdk/types/module/testutil"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	authTx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+	"github.com/cosmos/cosmos-sdk/x/auth/vesting"
 	"github.com/cosmos/cosmos-sdk/x/bank"
 	"github.com/cosmos/cosmos-sdk/x/consensus"
 	distr "github.com/cosmos/cosmos-sdk/x/distribution"
@@ -22,6 +27,7 @@ import (
 	genutiltypes "github.com/cosmos/cosmos-sdk/x/genutil/types"
 	"github.com/cosmos/cosmos-sdk/x/gov"
 	govclient "github.com/cosmos/cosmos-sdk/x/gov/client"
+	groupmodule "github.com/cosmos/cosmos-sdk/x/group/module"
 	"github.com/cosmos/cosmos-sdk/x/mint"
 	"github.com/cosmos/cosmos-sdk/x/params"
 	paramsclient "github.com/cosmos/cosmos-sdk/x/params/client"
@@ -32,8 +38,14 @@ import (
 )
 
 func DefaultEncoding() testutil.TestEncodingConfig {
-	return testutil.MakeTestEncodingConfig(
+	return testutil.MakeTestEncodingConfig(defaultModuleBasics()...)
+}
+
+// defaultModuleBasics are the modules of DefaultEncoding, also validating the genesis states of a GenesisBuilder.
+func defaultModuleBasics() []module.AppModuleBasic {
+	return []module.AppModuleBasic{
 		auth.AppModuleBasic{},
+		vesting.AppModuleBasic{},
 		genutil.NewAppModuleBasic(genutiltypes.DefaultMessageValidator),
 		bank.AppModuleBasic{},
 		capability.AppModuleBasic{},
@@ -45,16 +57,20 @@ func DefaultEncoding() testutil.TestEncodingConfig {
 				paramsclient.ProposalHandler,
 			},
 		),
+		groupmodule.AppModuleBasic{},
 		params.AppModuleBasic{},
 		slashing.AppModuleBasic{},
 		upgrade.AppModuleBasic{},
 		consensus.AppModuleBasic{},
+		circuit.AppModuleBasic{},
+		nftmodule.AppModuleBasic{},
 		transfer.AppModuleBasic{},
+		ica.AppModuleBasic{},
 		ibccore.AppModuleBasic{},
 		ibctm.AppModuleBasic{},
 		ibcwasm.AppModuleBasic{},
 		ccvprovider.AppModuleBasic{},
-	)
+	}
 }
 
 func decodeTX(interfaceRegistry codectypes.InterfaceRegistry, txbz []byte) (sdk.Tx, error) {
@@ -0,0 +1,85 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	libclient "github.com/cometbft/cometbft/rpc/jsonrpc/client"
+)
+
+// CometMock controls the CometMock replacing CometBFT in a chain, see ibc.CometMockConfig,
+// through its RPC methods, to produce blocks and advance time deterministically.
+type CometMock struct {
+	client *libclient.Client
+}
+
+// CometMock returns the controller of the CometMock of the chain, once started.
+func (c *CosmosChain) CometMock() (*CometMock, error) {
+	if !c.cfg.UsesCometMock() {
+		return nil, fmt.Errorf("chain %s does not use CometMock", c.cfg.ChainID)
+	}
+	node := c.GetNode()
+	if node.hostRPCPort == "" {
+		return nil, fmt.Errorf("chain %s is not started", c.cfg.ChainID)
+	}
+	client, err := libclient.New("tcp://" + node.hostRPCPort)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CometMock client: %w", err)
+	}
+	return &CometMock{client: client}, nil
+}
+
+// AdvanceBlocks produces n blocks, without waiting for the block time.
+// With ibc.CometMockConfig.ManualBlocks, blocks are only produced by AdvanceBlocks and transactions.
+func (m *CometMock) AdvanceBlocks(ctx context.Context, n int) error {
+	var res struct{}
+	if _, err := m.client.Call(ctx, "advance_blocks", map[string]interface{}{"num_blocks": n}, &res); err != nil {
+		return fmt.Errorf("failed to advance %d blocks: %w", n, err)
+	}
+	return nil
+}
+
+// AdvanceTime moves the time of the next blocks forward by d, a whole number of seconds,
+// e.g. to expire unbonding or IBC timeouts, and returns the new time.
+// The time of the latest block is unchanged until a block is produced.
+func (m *CometMock) AdvanceTime(ctx context.Context, d time.Duration) (time.Time, error) {
+	if d <= 0 || d%time.Second != 0 {
+		return time.Time{}, fmt.Errorf("cannot advance time by %s, not a positive whole number of seconds", d)
+	}
+	var res struct {
+		NewTime time.Time `json:"new_time"`
+	}
+	if _, err := m.client.Call(ctx, "advance_time", map[string]interface{}{"duration_in_seconds": int64(d / time.Second)}, &res); err != nil {
+		return time.Time{}, fmt.Errorf("failed to advance time by %s: %w", d, err)
+	}
+	return res.NewTime, nil
+}
+
+// SetSigning sets whether the validator val signs the blocks produced.
+// A validator not signing misses blocks, e.g. to be jailed for downtime, while the chain keeps producing blocks.
+func (m *CometMock) SetSigning(ctx context.Context, val *ChainNode, signing bool) error {
+	if !val.Validator {
+		return errors.New("cannot set the signing status of a full node")
+	}
+	addr, err := val.ConsensusAddress(ctx)
+	if err != nil {
+		return err
+	}
+	status := "down"
+	if signing {
+		status = "up"
+	}
+
+	var res struct {
+		NewSigningStatusMap map[string]bool `json:"new_signing_status_map"`
+	}
+	if _, err := m.client.Call(ctx, "set_signing_status", map[string]interface{}{
+		"private_key_address": addr,
+		"status":              status,
+	}, &res); err != nil {
+		return fmt.Errorf("failed to set signing status of %s %s: %w", val.Name(), status, err)
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package cosmos
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	libclient "github.com/cometbft/cometbft/rpc/jsonrpc/client"
+	"github.com/stretchr/testify/require"
+
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+)
+
+func TestCometMockNotUsed(t *testing.T) {
+	c := &CosmosChain{cfg: ibc.ChainConfig{ChainID: "test-1"}}
+	_, err := c.CometMock()
+	require.ErrorContains(t, err, "chain test-1 does not use CometMock")
+}
+
+func TestCometMockAdvanceTimeSeconds(t *testing.T) {
+	client, err := libclient.New("tcp://127.0.0.1:0")
+	require.NoError(t, err)
+	m := &CometMock{client: client}
+
+	for _, d := range []time.Duration{0, -time.Second, 1500 * time.Millisecond} {
+		_, err := m.AdvanceTime(context.Background(), d)
+		require.ErrorContains(t, err, "not a positive whole number of seconds")
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	dockerimagetypes "github.com/docker/docker/api/types/image"
 	volumetypes "github.com/docker/docker/api/types/volume"
@@ -37,6 +38,7 @@ import (
 
 	"github.com/strangelove-ventures/interchaintest/v8/blockdb"
 	wasmtypes "github.com/strangelove-ventures/interchaintest/v8/chain/cosmos/08-wasm-types"
+	"github.com/strangelove-ventures/interchaintest/v8/chain/internal/ibcquery"
 	"github.com/strangelove-ventures/interchaintest/v8/chain/internal/tendermint"
 	"github.com/strangelove-ventures/interchaintest/v8/dockerutil"
 	"github.com/strangelove-ventures/interchaintest/v8/ibc"
@@ -55,6 +57,15 @@ type CosmosChain struct {
 	Provider      *CosmosChain
 	Consumers     []*CosmosChain
 
+	// consumerID is the id of a permissionless consumer on its provider, see ICSConsumerID.
+	consumerID string
+	// spawnTime is the spawn time of a consumer on its provider.
+	spawnTime time.Time
+
+	// removedValidators are the validators removed with RemoveValidator,
+	// whose indexes, and so names and volumes, are not reused.
+	removedValidators ChainNodes
+
 	// preStartNodes is able to mutate the node containers before
 	// they are all started
 	preStartNodes func(*CosmosChain)
@@ -62,10 +73,13 @@ type CosmosChain struct {
 	// Additional processes that need to be run on a per-chain basis.
 	Sidecars SidecarProcesses
 
-	cdc      *codec.ProtoCodec
-	log      *zap.Logger
-	keyring  keyring.Keyring
-	findTxMu sync.Mutex
+	cdc *codec.ProtoCodec
+	log *zap.Logger
+	// keyring holds the keys of the chain with ibc.TxModeGRPC, the keys imported for BroadcastMsgs,
+	// and the relayer wallets. keyringMu guards it.
+	keyring   keyring.Keyring
+	keyringMu sync.RWMutex
+	findTxMu  sync.Mutex
 }
 
 func NewCosmosHeighlinerChainConfig(name string,
@@ -144,6 +158,12 @@ func (c *CosmosChain) Nodes() ChainNodes {
 
 // AddFullNodes adds new fullnodes to the network, peering with the existing nodes.
 func (c *CosmosChain) AddFullNodes(ctx context.Context, configFileOverrides map[string]any, inc int) error {
+	return c.addFullNodes(ctx, configFileOverrides, inc, nil)
+}
+
+// addFullNodes adds new fullnodes to the network, peering with the existing nodes.
+// If configure is not nil, it is called for each new node after its config files are written, before it starts.
+func (c *CosmosChain) addFullNodes(ctx context.Context, configFileOverrides map[string]any, inc int, configure func(*ChainNode) error) error {
 	// Get peer string for existing nodes
 	peers := c.Nodes().PeerString(ctx)
 
@@ -189,6 +209,11 @@ func (c *CosmosChain) AddFullNodes(ctx context.Context, configFileOverrides map[
 					return err
 				}
 			}
+			if configure != nil {
+				if err := configure(fn); err != nil {
+					return err
+				}
+			}
 			if err := fn.CreateNodeContainer(ctx); err != nil {
 				return err
 			}
@@ -198,6 +223,66 @@ func (c *CosmosChain) AddFullNodes(ctx context.Context, configFileOverrides map[
 	return eg.Wait()
 }
 
+// AddDuplicateValidator adds a fullnode that signs with the consensus key of val, so that val double-signs.
+// The new node is appended to FullNodes, and is returned once it is running.
+//
+// If isolated is false, the duplicate stays peered with the network,
+// which then receives conflicting votes of val as evidence of double-signing.
+//
+// If isolated is true, the duplicate accepts no peers after it caught up,
+// and signs blocks on its own from there, forking the chain.
+// This only produces blocks if val is the only validator of the chain.
+// Queries against the duplicate return the state of the fork.
+func (c *CosmosChain) AddDuplicateValidator(ctx context.Context, val *ChainNode, isolated bool) (*ChainNode, error) {
+	privVal, err := val.PrivValFileContent(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.AddFullNodes(ctx, nil, 1); err != nil {
+		return nil, err
+	}
+	dup := c.FullNodes[len(c.FullNodes)-1]
+
+	if err := testutil.WaitForInSync(ctx, c, dup); err != nil {
+		return nil, err
+	}
+
+	if err := dup.StopContainer(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := dup.OverwritePrivValFile(ctx, privVal); err != nil {
+		return nil, err
+	}
+
+	if isolated {
+		p2p := make(testutil.Toml)
+		p2p["persistent_peers"] = ""
+		p2p["pex"] = false
+		p2p["max_num_inbound_peers"] = 0
+		p2p["max_num_outbound_peers"] = 0
+
+		if err := testutil.ModifyTomlConfigFile(
+			ctx,
+			dup.logger(),
+			dup.DockerClient,
+			dup.TestName,
+			dup.VolumeName,
+			"config/config.toml",
+			testutil.Toml{"p2p": p2p},
+		); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := dup.StartContainer(ctx); err != nil {
+		return nil, err
+	}
+
+	return dup, nil
+}
+
 // Implements Chain interface.
 func (c *CosmosChain) Config() ibc.ChainConfig {
 	return c.cfg
@@ -224,6 +309,13 @@ func (c *CosmosChain) Exec(ctx context.Context, cmd []string, env []string) (std
 	return c.GetFullNode().Exec(ctx, cmd, env)
 }
 
+var _ ibc.IBCQueryChain = (*CosmosChain)(nil)
+
+// IBCQuerier implements ibc.IBCQueryChain.
+func (c *CosmosChain) IBCQuerier() ibc.IBCQuerier {
+	return ibcquery.New(c.GetNode().GrpcConn, c.Config().EncodingConfig.InterfaceRegistry)
+}
+
 // Implements Chain interface.
 func (c *CosmosChain) GetRPCAddress() string {
 	if c.Config().UsesCometMock() {
@@ -273,13 +365,26 @@ func (c *CosmosChain) HomeDir() string {
 }
 
 // Implements Chain interface.
+// If the chain uses ibc.TxModeGRPC, the key is created in the keyring of the chain and can sign on any node.
 func (c *CosmosChain) CreateKey(ctx context.Context, keyName string) error {
-	return c.GetFullNode().CreateKey(ctx, keyName)
+	return c.RecoverKey(ctx, keyName, "")
 }
 
 // Implements Chain interface.
+// If the chain uses ibc.TxModeGRPC, the key is restored in the keyring of the chain and can sign on any node.
 func (c *CosmosChain) RecoverKey(ctx context.Context, keyName, mnemonic string) error {
-	return c.GetFullNode().RecoverKey(ctx, keyName, mnemonic)
+	tn := c.GetFullNode()
+	if !tn.UsesGRPCTx() {
+		if mnemonic == "" {
+			return tn.CreateKey(ctx, keyName)
+		}
+		return tn.RecoverKey(ctx, keyName, mnemonic)
+	}
+
+	tn.lock.Lock()
+	defer tn.lock.Unlock()
+
+	return tn.createKey(ctx, keyName, keyName, mnemonic)
 }
 
 // Implements Chain interface.
@@ -323,6 +428,7 @@ func (c *CosmosChain) BuildRelayerWallet(ctx context.Context, keyName string) (i
 		return nil, fmt.Errorf("invalid coin type: %w", err)
 	}
 
+	c.keyringMu.Lock()
 	info, mnemonic, err := c.keyring.NewMnemonic(
 		keyName,
 		keyring.English,
@@ -330,6 +436,7 @@ func (c *CosmosChain) BuildRelayerWallet(ctx context.Context, keyName string) (i
 		"", // Empty passphrase.
 		hd.Secp256k1,
 	)
+	c.keyringMu.Unlock()
 	if err != nil {
 		return nil, fmt.Errorf("failed to create mnemonic: %w", err)
 	}
@@ -364,6 +471,11 @@ func (c *CosmosChain) SendIBCTransfer(
 	if err != nil {
 		return tx, fmt.Errorf("send ibc transfer: %w", err)
 	}
+	return c.packetTx(txHash)
+}
+
+// packetTx returns the transaction txHash, with the packet from its send_packet event.
+func (c *CosmosChain) packetTx(txHash string) (tx ibc.Tx, _ error) {
 	txResp, err := c.GetTransaction(txHash)
 	if err != nil {
 		return tx, fmt.Errorf("failed to get transaction %s: %w", txHash, err)
@@ -417,17 +529,20 @@ func (c *CosmosChain) SendIBCTransfer(
 }
 
 // RegisterICA will attempt to register an interchain account on the given counterparty chain.
+// Deprecated: use ICARegister instead.
 func (c *CosmosChain) RegisterICA(ctx context.Context, keyName string, connectionID string) (string, error) {
 	return c.GetFullNode().RegisterICA(ctx, keyName, connectionID)
 }
 
 // QueryICA will query for an interchain account controlled by the given address on the counterparty chain.
+// Deprecated: use ICAQueryAddress instead.
 func (c *CosmosChain) QueryICAAddress(ctx context.Context, connectionID, address string) (string, error) {
 	return c.GetFullNode().QueryICA(ctx, connectionID, address)
 }
 
 // SendICATx sends an interchain account transaction for a specified address and sends it to the respective
 // interchain account on the counterparty chain.
+// Deprecated: use ICAExecute instead.
 func (c *CosmosChain) SendICATx(ctx context.Context, keyName, connectionID string, msgs []sdk.Msg, icaTxMemo string) (string, error) {
 	node := c.GetFullNode()
 	registry := node.Chain.Config().EncodingConfig.InterfaceRegistry
@@ -618,24 +733,30 @@ func (c *CosmosChain) UpgradeVersion(ctx context.Context, cli *client.Client, co
 
 func (c *CosmosChain) pullImages(ctx context.Context, cli *client.Client) {
 	for _, image := range c.Config().Images {
-		if image.Version == "local" {
-			continue
-		}
-		rc, err := cli.ImagePull(
-			ctx,
-			image.Repository+":"+image.Version,
-			dockerimagetypes.PullOptions{},
+		c.pullImage(ctx, cli, image)
+	}
+}
+
+// pullImage pulls image unless it is a local build, logging instead of failing
+// so that images only present locally can still be used.
+func (c *CosmosChain) pullImage(ctx context.Context, cli *client.Client, image ibc.DockerImage) {
+	if image.Version == "local" {
+		return
+	}
+	rc, err := cli.ImagePull(
+		ctx,
+		image.Repository+":"+image.Version,
+		dockerimagetypes.PullOptions{},
+	)
+	if err != nil {
+		c.log.Error("Failed to pull image",
+			zap.Error(err),
+			zap.String("repository", image.Repository),
+			zap.String("tag", image.Version),
 		)
-		if err != nil {
-			c.log.Error("Failed to pull image",
-				zap.Error(err),
-				zap.String("repository", image.Repository),
-				zap.String("tag", image.Version),
-			)
-		} else {
-			_, _ = io.Copy(io.Discard, rc)
-			_ = rc.Close()
-		}
+	} else {
+		_, _ = io.Copy(io.Discard, rc)
+		_ = rc.Close()
 	}
 }
 
@@ -834,6 +955,17 @@ type ValidatorWithIntPower struct {
 	PubKeyBase64 string
 }
 
+// validatorAmounts returns the funds and the self-delegation of the i-th validator.
+func (c *CosmosChain) validatorAmounts(i int) (amount, selfDelegation sdk.Coin) {
+	if c.cfg.ModifyGenesisAmounts != nil {
+		return c.cfg.ModifyGenesisAmounts(i)
+	}
+	decimalPow := int64(math.Pow10(int(*c.cfg.CoinDecimals)))
+	amount = sdk.Coin{Amount: sdkmath.NewInt(10_000_000).MulRaw(decimalPow), Denom: c.cfg.Denom}
+	selfDelegation = sdk.Coin{Amount: sdkmath.NewInt(5_000_000).MulRaw(decimalPow), Denom: c.cfg.Denom}
+	return amount, selfDelegation
+}
+
 // Bootstraps the chain and starts it from genesis.
 func (c *CosmosChain) Start(testName string, ctx context.Context, additionalGenesisWallets ...ibc.WalletAmount) error {
 	if c.cfg.InterchainSecurityConfig.ConsumerCopyProviderKey != nil && c.Provider == nil {
@@ -842,19 +974,13 @@ func (c *CosmosChain) Start(testName string, ctx context.Context, additionalGene
 
 	chainCfg := c.Config()
 
-	decimalPow := int64(math.Pow10(int(*chainCfg.CoinDecimals)))
-
 	genesisAmounts := make([][]sdk.Coin, len(c.Validators))
 	genesisSelfDelegation := make([]sdk.Coin, len(c.Validators))
 
 	for i := range c.Validators {
-		genesisAmounts[i] = []sdk.Coin{{Amount: sdkmath.NewInt(10_000_000).MulRaw(decimalPow), Denom: chainCfg.Denom}}
-		genesisSelfDelegation[i] = sdk.Coin{Amount: sdkmath.NewInt(5_000_000).MulRaw(decimalPow), Denom: chainCfg.Denom}
-		if chainCfg.ModifyGenesisAmounts != nil {
-			amount, selfDelegation := chainCfg.ModifyGenesisAmounts(i)
-			genesisAmounts[i] = []sdk.Coin{amount}
-			genesisSelfDelegation[i] = selfDelegation
-		}
+		amount, selfDelegation := c.validatorAmounts(i)
+		genesisAmounts[i] = []sdk.Coin{amount}
+		genesisSelfDelegation[i] = selfDelegation
 	}
 
 	configFileOverrides := chainCfg.ConfigFileOverrides
@@ -884,7 +1010,11 @@ func (c *CosmosChain) Start(testName string, ctx context.Context, additionalGene
 					return fmt.Errorf("failed to modify toml config file: %w", err)
 				}
 			}
-			if !c.cfg.SkipGenTx {
+			switch {
+			case c.cfg.Genesis != nil:
+				// The validators take over validators of the genesis, see forkGenesis.
+				return v.CreateKey(ctx, valKey)
+			case !c.cfg.SkipGenTx:
 				return v.InitValidatorGenTx(ctx, &chainCfg, genesisAmounts[i], genesisSelfDelegation[i])
 			}
 			return nil
@@ -935,47 +1065,19 @@ func (c *CosmosChain) Start(testName string, ctx context.Context, additionalGene
 		}
 	}
 
-	// for the validators we need to collect the gentxs and the accounts
-	// to the first node's genesis file
-	validator0 := c.Validators[0]
-	for i := 1; i < len(c.Validators); i++ {
-		validatorN := c.Validators[i]
-
-		bech32, err := validatorN.AccountKeyBech32(ctx, valKey)
-		if err != nil {
-			return err
-		}
-
-		if err := validator0.AddGenesisAccount(ctx, bech32, genesisAmounts[0]); err != nil {
-			return err
-		}
-
-		if !c.cfg.SkipGenTx {
-			if err := validatorN.copyGentx(ctx, validator0); err != nil {
-				return err
-			}
-		}
-	}
-
-	for _, wallet := range additionalGenesisWallets {
-		if err := validator0.AddGenesisAccount(ctx, wallet.Address, []sdk.Coin{{Denom: wallet.Denom, Amount: wallet.Amount}}); err != nil {
-			return err
-		}
-	}
-
-	if !c.cfg.SkipGenTx {
-		if err := validator0.CollectGentxs(ctx); err != nil {
-			return err
-		}
+	var (
+		genbz []byte
+		err   error
+	)
+	if c.cfg.Genesis != nil {
+		genbz, err = c.forkGenesis(ctx, additionalGenesisWallets...)
+	} else {
+		genbz, err = c.collectGenesis(ctx, genesisAmounts, additionalGenesisWallets...)
 	}
-
-	genbz, err := validator0.GenesisFileContent(ctx)
 	if err != nil {
 		return err
 	}
 
-	genbz = bytes.ReplaceAll(genbz, []byte(`"stake"`), []byte(fmt.Sprintf(`"%s"`, chainCfg.Denom)))
-
 	if c.cfg.ModifyGenesis != nil {
 		genbz, err = c.cfg.ModifyGenesis(chainCfg, genbz)
 		if err != nil {
@@ -1055,6 +1157,97 @@ func (c *CosmosChain) Start(testName string, ctx context.Context, additionalGene
 	}
 
 	// Wait for blocks before considering the chains "started"
+	if c.cfg.UsesCometMock() && c.cfg.CometMock.ManualBlocks {
+		mock, err := c.CometMock()
+		if err != nil {
+			return err
+		}
+		return mock.AdvanceBlocks(ctx, 2)
+	}
+	return testutil.WaitForBlocks(ctx, 2, c.GetFullNode())
+}
+
+// collectGenesis returns the genesis of a new chain, with the accounts and gentxs of the validators and additionalGenesisWallets.
+func (c *CosmosChain) collectGenesis(ctx context.Context, genesisAmounts [][]sdk.Coin, additionalGenesisWallets ...ibc.WalletAmount) ([]byte, error) {
+	// for the validators we need to collect the gentxs and the accounts
+	// to the first node's genesis file
+	validator0 := c.Validators[0]
+	for i := 1; i < len(c.Validators); i++ {
+		validatorN := c.Validators[i]
+
+		bech32, err := validatorN.AccountKeyBech32(ctx, valKey)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := validator0.AddGenesisAccount(ctx, bech32, genesisAmounts[0]); err != nil {
+			return nil, err
+		}
+
+		if !c.cfg.SkipGenTx {
+			if err := validatorN.copyGentx(ctx, validator0); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, wallet := range additionalGenesisWallets {
+		if err := validator0.AddGenesisAccount(ctx, wallet.Address, []sdk.Coin{{Denom: wallet.Denom, Amount: wallet.Amount}}); err != nil {
+			return nil, err
+		}
+	}
+
+	if !c.cfg.SkipGenTx {
+		if err := validator0.CollectGentxs(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	genbz, err := validator0.GenesisFileContent(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.ReplaceAll(genbz, []byte(`"stake"`), []byte(fmt.Sprintf(`"%s"`, c.cfg.Denom))), nil
+}
+
+// StartFromVolumes starts the chain from node volumes that already hold a complete home directory,
+// e.g. one restored with ChainNode.ImportVolume from a snapshot of a previously running chain,
+// instead of bootstrapping it from genesis.
+// Peers are reconfigured because node hostnames depend on the test name.
+func (c *CosmosChain) StartFromVolumes(ctx context.Context) error {
+	if c.cfg.UsesCometMock() {
+		return fmt.Errorf("starting from existing volumes is not supported with CometMock")
+	}
+
+	chainNodes := c.Nodes()
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	for _, n := range chainNodes {
+		eg.Go(func() error {
+			return n.CreateNodeContainer(egCtx)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	peers := chainNodes.PeerString(ctx)
+
+	eg, egCtx = errgroup.WithContext(ctx)
+	for _, n := range chainNodes {
+		c.log.Info("Starting container", zap.String("container", n.Name()))
+		eg.Go(func() error {
+			if err := n.SetPeers(egCtx, peers); err != nil {
+				return err
+			}
+			return n.StartContainer(egCtx)
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
 	return testutil.WaitForBlocks(ctx, 2, c.GetFullNode())
 }
 
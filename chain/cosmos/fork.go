@@ -0,0 +1,266 @@
+package cosmos
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"go.uber.org/zap"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+)
+
+// DefaultForkMaxVals is the default ibc.GenesisConfig.MaxVals of a fork.
+const DefaultForkMaxVals = 10
+
+// ForkGenesis returns the genesis config forking the state exported by ExportState at height,
+// e.g. of a chain reproducing a production state. The ibc.ChainConfig.Genesis of the fork is set to it.
+func (c *CosmosChain) ForkGenesis(ctx context.Context, height int64) (*ibc.GenesisConfig, error) {
+	state, err := c.ExportState(ctx, height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export state at height %d: %w", height, err)
+	}
+	return &ibc.GenesisConfig{Contents: []byte(state)}, nil
+}
+
+// forkValidator is a validator of a forked genesis.
+type forkValidator struct {
+	address string
+	pubKey  string
+	power   int64
+}
+
+// forkGenesis returns the exported genesis of ibc.ChainConfig.Genesis, forked like an in-place testnet:
+// the validators of the chain take over the validators with the most power of the genesis,
+// more than two thirds of its voting power or all of them with AllValidators,
+// and the additionalGenesisWallets are funded.
+//
+// The consensus keys of the validators taken over are replaced in the consensus, staking, slashing
+// and distribution states, and their operators by the validator keys of the nodes, whose accounts
+// keep the balances and delegations of the operators.
+// The validators not taken over keep their power, and miss blocks.
+func (c *CosmosChain) forkGenesis(ctx context.Context, additionalGenesisWallets ...ibc.WalletAmount) ([]byte, error) {
+	genesis := c.cfg.Genesis
+	b, err := NewGenesisBuilder(c.cfg, genesis.Contents)
+	if err != nil {
+		return nil, err
+	}
+	vals, err := forkValidators(b)
+	if err != nil {
+		return nil, err
+	}
+
+	maxVals := genesis.MaxVals
+	if maxVals <= 0 {
+		maxVals = DefaultForkMaxVals
+	}
+	n := forkValidatorCount(vals, genesis.AllValidators)
+	switch {
+	case n > maxVals:
+		return nil, fmt.Errorf("taking over %d validators of the genesis exceeds its MaxVals %d", n, maxVals)
+	case n > len(c.Validators):
+		return nil, fmt.Errorf("taking over the validators of the genesis needs %d validators, not %d", n, len(c.Validators))
+	case n < len(c.Validators):
+		c.log.Warn("More validators than needed to take over the genesis, extra validators do not validate",
+			zap.Int("needed", n), zap.Int("validators", len(c.Validators)))
+	}
+
+	staking, err := b.Get("app_state.staking.validators")
+	if err != nil {
+		return nil, err
+	}
+
+	genbz := genesis.Contents
+	replace := func(old, new string) {
+		genbz = bytes.ReplaceAll(genbz, []byte(strconv.Quote(old)), []byte(strconv.Quote(new)))
+	}
+
+	valconsPrefix := c.cfg.Bech32Prefix + sdk.PrefixValidator + sdk.PrefixConsensus
+	operators := make([]string, n)
+	for i, val := range vals[:n] {
+		node := c.Validators[i]
+		nodeAddr, nodePubKey, err := node.consensusKey(ctx)
+		if err != nil {
+			return nil, err
+		}
+		valcons, err := hexToBech32(valconsPrefix, val.address)
+		if err != nil {
+			return nil, err
+		}
+		nodeValcons, err := hexToBech32(valconsPrefix, nodeAddr)
+		if err != nil {
+			return nil, err
+		}
+
+		valoper, err := forkOperator(staking, val.pubKey)
+		if err != nil {
+			return nil, err
+		}
+		_, bz, err := bech32.DecodeAndConvert(valoper)
+		if err != nil {
+			return nil, fmt.Errorf("malformed operator address %s: %w", valoper, err)
+		}
+		operator, err := bech32.ConvertAndEncode(c.cfg.Bech32Prefix, bz)
+		if err != nil {
+			return nil, err
+		}
+		nodeValoper, err := node.KeyBech32(ctx, valKey, "val")
+		if err != nil {
+			return nil, err
+		}
+		if operators[i], err = node.AccountKeyBech32(ctx, valKey); err != nil {
+			return nil, err
+		}
+
+		c.log.Info("Taking over validator",
+			zap.String("validator", node.Name()),
+			zap.String("operator", valoper),
+			zap.Int64("power", val.power),
+		)
+		replace(val.pubKey, nodePubKey)
+		replace(val.address, nodeAddr)
+		replace(valcons, nodeValcons)
+		replace(valoper, nodeValoper)
+		replace(operator, operators[i])
+	}
+
+	if b, err = NewGenesisBuilder(c.cfg, genbz); err != nil {
+		return nil, err
+	}
+	if err := b.Set("chain_id", c.cfg.ChainID); err != nil {
+		return nil, err
+	}
+	// The operator accounts are controlled by other keys now, their public keys are set again by their first transaction.
+	accounts, err := b.Get("app_state.auth.accounts")
+	if err != nil {
+		return nil, err
+	}
+	accs, ok := accounts.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("malformed auth accounts of type %T", accounts)
+	}
+	for _, acc := range accs {
+		clearPubKey(acc, operators)
+	}
+	if genbz, err = b.Bytes(); err != nil {
+		return nil, err
+	}
+
+	validator0 := c.Validators[0]
+	if err := validator0.OverwriteGenesisFile(ctx, genbz); err != nil {
+		return nil, err
+	}
+	for _, wallet := range additionalGenesisWallets {
+		if err := validator0.AddGenesisAccount(ctx, wallet.Address, []sdk.Coin{{Denom: wallet.Denom, Amount: wallet.Amount}}); err != nil {
+			return nil, err
+		}
+	}
+	return validator0.GenesisFileContent(ctx)
+}
+
+// forkValidators returns the CometBFT validators of the genesis, by decreasing power.
+func forkValidators(b *GenesisBuilder) ([]forkValidator, error) {
+	raw, err := b.Get("consensus.validators")
+	if err != nil {
+		// Before SDK v0.50, the consensus validators are at the top level.
+		if raw, err = b.Get("validators"); err != nil {
+			return nil, fmt.Errorf("no validators in genesis: %w", err)
+		}
+	}
+	list, ok := raw.([]interface{})
+	if !ok || len(list) == 0 {
+		return nil, fmt.Errorf("no validators in genesis")
+	}
+
+	vals := make([]forkValidator, len(list))
+	for i, v := range list {
+		val, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("malformed genesis validator %d", i)
+		}
+		pubKey, _ := val["pub_key"].(map[string]interface{})
+		vals[i].address, _ = val["address"].(string)
+		vals[i].pubKey, _ = pubKey["value"].(string)
+		power, err := strconv.ParseInt(fmt.Sprint(val["power"]), 10, 64)
+		if err != nil || vals[i].address == "" || vals[i].pubKey == "" {
+			return nil, fmt.Errorf("malformed genesis validator %d", i)
+		}
+		vals[i].power = power
+	}
+	slices.SortStableFunc(vals, func(a, b forkValidator) int {
+		switch {
+		case a.power > b.power:
+			return -1
+		case a.power < b.power:
+			return 1
+		}
+		return 0
+	})
+	return vals, nil
+}
+
+// forkValidatorCount returns the number of validators with the most power, from vals sorted by decreasing power,
+// holding more than two thirds of the voting power, or all of them.
+func forkValidatorCount(vals []forkValidator, all bool) int {
+	if all {
+		return len(vals)
+	}
+	var total, power int64
+	for _, val := range vals {
+		total += val.power
+	}
+	for i, val := range vals {
+		power += val.power
+		if 3*power > 2*total {
+			return i + 1
+		}
+	}
+	return len(vals)
+}
+
+// forkOperator returns the operator address of the validator with the consensus public key pubKey,
+// among the staking validators of a genesis.
+func forkOperator(stakingValidators interface{}, pubKey string) (string, error) {
+	vals, _ := stakingValidators.([]interface{})
+	for _, v := range vals {
+		val, _ := v.(map[string]interface{})
+		consPubKey, _ := val["consensus_pubkey"].(map[string]interface{})
+		if consPubKey["key"] == pubKey {
+			if operator, ok := val["operator_address"].(string); ok {
+				return operator, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no staking validator with consensus public key %s", pubKey)
+}
+
+// clearPubKey clears the public key of acc, and of the accounts it embeds, if its address is in addresses.
+func clearPubKey(acc interface{}, addresses []string) {
+	m, ok := acc.(map[string]interface{})
+	if !ok {
+		return
+	}
+	if addr, ok := m["address"].(string); ok && slices.Contains(addresses, addr) {
+		if _, ok := m["pub_key"]; ok {
+			m["pub_key"] = nil
+		}
+	}
+	for _, v := range m {
+		clearPubKey(v, addresses)
+	}
+}
+
+// hexToBech32 encodes the hex address addr as a bech32 address with prefix.
+func hexToBech32(prefix, addr string) (string, error) {
+	bz, err := hex.DecodeString(addr)
+	if err != nil {
+		return "", fmt.Errorf("malformed hex address %s: %w", addr, err)
+	}
+	return bech32.ConvertAndEncode(prefix, bz)
+}
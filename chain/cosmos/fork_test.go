@@ -0,0 +1,52 @@
+package cosmos
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+)
+
+func TestForkValidators(t *testing.T) {
+	for _, genesis := range []string{
+		`{"consensus":{"validators":[{"address":"AA","pub_key":{"value":"a"},"power":"10"},{"address":"BB","pub_key":{"value":"b"},"power":"30"}]}}`,
+		`{"validators":[{"address":"AA","pub_key":{"value":"a"},"power":"10"},{"address":"BB","pub_key":{"value":"b"},"power":"30"}]}`,
+	} {
+		b, err := NewGenesisBuilder(ibc.ChainConfig{}, []byte(genesis))
+		require.NoError(t, err)
+		vals, err := forkValidators(b)
+		require.NoError(t, err)
+		require.Equal(t, []forkValidator{{address: "BB", pubKey: "b", power: 30}, {address: "AA", pubKey: "a", power: 10}}, vals)
+	}
+
+	b, err := NewGenesisBuilder(ibc.ChainConfig{}, []byte(`{"consensus":{}}`))
+	require.NoError(t, err)
+	_, err = forkValidators(b)
+	require.ErrorContains(t, err, "no validators in genesis")
+}
+
+func TestForkValidatorCount(t *testing.T) {
+	vals := []forkValidator{{power: 50}, {power: 20}, {power: 20}, {power: 10}}
+	require.Equal(t, 2, forkValidatorCount(vals, false))
+	require.Equal(t, 4, forkValidatorCount(vals, true))
+	require.Equal(t, 1, forkValidatorCount([]forkValidator{{power: 70}, {power: 30}}, false))
+	require.Equal(t, 2, forkValidatorCount([]forkValidator{{power: 50}, {power: 50}}, false))
+}
+
+func TestClearPubKey(t *testing.T) {
+	acc := map[string]interface{}{
+		"@type": "/cosmos.vesting.v1beta1.DelayedVestingAccount",
+		"base_vesting_account": map[string]interface{}{
+			"base_account": map[string]interface{}{"address": "cosmos1a", "pub_key": map[string]interface{}{"key": "k"}},
+		},
+	}
+	other := map[string]interface{}{"address": "cosmos1b", "pub_key": map[string]interface{}{"key": "k"}}
+
+	clearPubKey(acc, []string{"cosmos1a"})
+	clearPubKey(other, []string{"cosmos1a"})
+
+	base := acc["base_vesting_account"].(map[string]interface{})["base_account"].(map[string]interface{})
+	require.Nil(t, base["pub_key"])
+	require.NotNil(t, other["pub_key"])
+}
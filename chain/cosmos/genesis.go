@@ -31,17 +31,7 @@ func ModifyGenesis(genesisKV []GenesisKV) func(ibc.ChainConfig, []byte) ([]byte,
 		}
 
 		for idx, values := range genesisKV {
-			splitPath := strings.Split(values.Key, ".")
-
-			path := make([]interface{}, len(splitPath))
-			for i, component := range splitPath {
-				if v, err := strconv.Atoi(component); err == nil {
-					path[i] = v
-				} else {
-					path[i] = component
-				}
-			}
-
+			path := genesisPath(values.Key)
 			if err := dyno.Set(g, values.Value, path...); err != nil {
 				return nil, fmt.Errorf("failed to set key '%s' as '%+v' (index:%d) in genesis json: %w", values.Key, values.Value, idx, err)
 			}
@@ -54,3 +44,18 @@ func ModifyGenesis(genesisKV []GenesisKV) func(ibc.ChainConfig, []byte) ([]byte,
 		return out, nil
 	}
 }
+
+// genesisPath splits the dotted path key of a genesis value, whose integer components index arrays.
+func genesisPath(key string) []interface{} {
+	splitPath := strings.Split(key, ".")
+
+	path := make([]interface{}, len(splitPath))
+	for i, component := range splitPath {
+		if v, err := strconv.Atoi(component); err == nil {
+			path[i] = v
+		} else {
+			path[i] = component
+		}
+	}
+	return path
+}
@@ -0,0 +1,343 @@
+package cosmos
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/icza/dyno"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	vestingtypes "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	"github.com/cosmos/gogoproto/proto"
+
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+)
+
+// GenesisBuilder modifies the genesis of a chain with Go code.
+//
+// ModuleGenesis decodes the genesis state of a module into its GenesisState with the codec of the chain,
+// to be modified in place. The modified states are validated with the ValidateGenesis of their module
+// when the genesis is encoded, which checks addresses against the prefix of the SDK config, see SetSDKConfig.
+//
+// Set modifies a value by dotted path like ModifyGenesis, but fails on paths not in the genesis.
+type GenesisBuilder struct {
+	cdc      codec.Codec
+	txConfig client.TxConfig
+	genesis  map[string]interface{}
+
+	// modules are the decoded genesis states of modules, encoded back into genesis by flush.
+	modules map[string]proto.Message
+}
+
+// NewGenesisBuilder decodes genbz with the encoding config of chainConfig, or DefaultEncoding if not set.
+func NewGenesisBuilder(chainConfig ibc.ChainConfig, genbz []byte) (*GenesisBuilder, error) {
+	encoding := chainConfig.EncodingConfig
+	if encoding == nil {
+		enc := DefaultEncoding()
+		encoding = &enc
+	}
+
+	// Keep numbers as they are, e.g. large amounts, instead of float64.
+	dec := json.NewDecoder(bytes.NewReader(genbz))
+	dec.UseNumber()
+	g := make(map[string]interface{})
+	if err := dec.Decode(&g); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal genesis file: %w", err)
+	}
+
+	return &GenesisBuilder{
+		cdc:      encoding.Codec,
+		txConfig: encoding.TxConfig,
+		genesis:  g,
+		modules:  make(map[string]proto.Message),
+	}, nil
+}
+
+// ModifyGenesisTyped returns a ModifyGenesis function, to set as ibc.ChainConfig.ModifyGenesis,
+// applying modifiers in order to the GenesisBuilder of the genesis.
+func ModifyGenesisTyped(modifiers ...func(*GenesisBuilder) error) func(ibc.ChainConfig, []byte) ([]byte, error) {
+	return func(chainConfig ibc.ChainConfig, genbz []byte) ([]byte, error) {
+		b, err := NewGenesisBuilder(chainConfig, genbz)
+		if err != nil {
+			return nil, err
+		}
+		for _, modify := range modifiers {
+			if err := modify(b); err != nil {
+				return nil, err
+			}
+		}
+		return b.Bytes()
+	}
+}
+
+// ModifyGenesisStrict is ModifyGenesis failing on keys not in the genesis instead of creating them.
+func ModifyGenesisStrict(genesisKV []GenesisKV) func(ibc.ChainConfig, []byte) ([]byte, error) {
+	return ModifyGenesisTyped(func(b *GenesisBuilder) error {
+		for _, kv := range genesisKV {
+			if err := b.Set(kv.Key, kv.Value); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ModuleGenesis returns the genesis state of module in b, e.g. ModuleGenesis[govv1.GenesisState](b, "gov").
+// The state is decoded once and its changes are kept: the same state is returned until Get, Set or Bytes
+// encode it back into the genesis.
+func ModuleGenesis[T any, PT interface {
+	*T
+	proto.Message
+}](b *GenesisBuilder, module string) (PT, error) {
+	if state, ok := b.modules[module]; ok {
+		s, ok := state.(PT)
+		if !ok {
+			return nil, fmt.Errorf("genesis state of module %s is a %T, not a %T", module, state, s)
+		}
+		return s, nil
+	}
+
+	raw, err := b.Get("app_state." + module)
+	if err != nil {
+		return nil, err
+	}
+	bz, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal genesis state of module %s: %w", module, err)
+	}
+	state := PT(new(T))
+	if err := b.cdc.UnmarshalJSON(bz, state); err != nil {
+		return nil, fmt.Errorf("failed to decode genesis state of module %s as %T: %w", module, state, err)
+	}
+	b.modules[module] = state
+	return state, nil
+}
+
+// ModifyModuleGenesis returns a GenesisBuilder modifier applying modify to the genesis state of module.
+func ModifyModuleGenesis[T any, PT interface {
+	*T
+	proto.Message
+}](module string, modify func(PT) error) func(*GenesisBuilder) error {
+	return func(b *GenesisBuilder) error {
+		state, err := ModuleGenesis[T, PT](b, module)
+		if err != nil {
+			return err
+		}
+		return modify(state)
+	}
+}
+
+// Get returns the value at the dotted path key, e.g. "app_state.gov.params".
+func (b *GenesisBuilder) Get(key string) (interface{}, error) {
+	path := genesisPath(key)
+	if err := b.flushPath(path); err != nil {
+		return nil, err
+	}
+	v, err := dyno.Get(b.genesis, path...)
+	if err != nil {
+		return nil, fmt.Errorf("unknown genesis path %q: %w", key, err)
+	}
+	return v, nil
+}
+
+// Set sets the value at the dotted path key, which must be in the genesis.
+func (b *GenesisBuilder) Set(key string, value interface{}) error {
+	path := genesisPath(key)
+	if err := b.flushPath(path); err != nil {
+		return err
+	}
+	if _, err := dyno.Get(b.genesis, path...); err != nil {
+		return fmt.Errorf("unknown genesis path %q: %w", key, err)
+	}
+	if err := dyno.Set(b.genesis, value, path...); err != nil {
+		return fmt.Errorf("failed to set key '%s' as '%+v' in genesis json: %w", key, value, err)
+	}
+	return nil
+}
+
+// Bytes validates the modified genesis states of modules and returns the genesis.
+func (b *GenesisBuilder) Bytes() ([]byte, error) {
+	modules := make([]string, 0, len(b.modules))
+	for module := range b.modules {
+		modules = append(modules, module)
+	}
+	slices.Sort(modules)
+	for _, module := range modules {
+		if err := b.flush(module); err != nil {
+			return nil, err
+		}
+	}
+
+	out, err := json.Marshal(b.genesis)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal genesis bytes to json: %w", err)
+	}
+	return out, nil
+}
+
+// flushPath flushes the genesis state of the module of path, if decoded, for path to see its changes.
+func (b *GenesisBuilder) flushPath(path []interface{}) error {
+	if len(path) < 2 || path[0] != "app_state" {
+		return nil
+	}
+	module, ok := path[1].(string)
+	if !ok {
+		return nil
+	}
+	if _, ok := b.modules[module]; !ok {
+		return nil
+	}
+	return b.flush(module)
+}
+
+// flush validates the decoded genesis state of module and encodes it back into the genesis.
+func (b *GenesisBuilder) flush(module string) error {
+	state := b.modules[module]
+	bz, err := b.cdc.MarshalJSON(state)
+	if err != nil {
+		return fmt.Errorf("failed to encode genesis state of module %s: %w", module, err)
+	}
+	if err := b.validate(module, state, bz); err != nil {
+		return fmt.Errorf("invalid genesis state of module %s: %w", module, err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(bz))
+	dec.UseNumber()
+	var raw interface{}
+	if err := dec.Decode(&raw); err != nil {
+		return fmt.Errorf("failed to unmarshal genesis state of module %s: %w", module, err)
+	}
+	if err := dyno.Set(b.genesis, raw, "app_state", module); err != nil {
+		return fmt.Errorf("failed to set genesis state of module %s: %w", module, err)
+	}
+	delete(b.modules, module)
+	return nil
+}
+
+// validate validates the genesis state of the module name with its ValidateGenesis, if it is a module of DefaultEncoding,
+// or else with the Validate method of the state, if any.
+func (b *GenesisBuilder) validate(name string, state proto.Message, bz json.RawMessage) error {
+	if m, ok := basicModule(name).(module.HasGenesisBasics); ok {
+		return m.ValidateGenesis(b.cdc, b.txConfig, bz)
+	}
+	if v, ok := state.(interface{ Validate() error }); ok {
+		return v.Validate()
+	}
+	return nil
+}
+
+// basicModule returns the module named name of DefaultEncoding, or nil.
+func basicModule(name string) module.AppModuleBasic {
+	for _, m := range defaultModuleBasics() {
+		if m.Name() == name {
+			return m
+		}
+	}
+	return nil
+}
+
+// GenesisVotingPeriod returns a GenesisBuilder modifier setting the voting period of governance proposals.
+func GenesisVotingPeriod(votingPeriod time.Duration) func(*GenesisBuilder) error {
+	return ModifyModuleGenesis(govtypes.ModuleName, func(gov *govv1.GenesisState) error {
+		gov.Params.VotingPeriod = &votingPeriod
+		if gov.Params.ExpeditedVotingPeriod != nil && *gov.Params.ExpeditedVotingPeriod >= votingPeriod {
+			// The expedited voting period must be shorter.
+			expedited := votingPeriod / 2
+			gov.Params.ExpeditedVotingPeriod = &expedited
+		}
+		return nil
+	})
+}
+
+// GenesisDenomMetadata returns a GenesisBuilder modifier adding the metadata of denoms to the bank module.
+func GenesisDenomMetadata(metadata ...banktypes.Metadata) func(*GenesisBuilder) error {
+	return ModifyModuleGenesis(banktypes.ModuleName, func(bank *banktypes.GenesisState) error {
+		bank.DenomMetadata = append(bank.DenomMetadata, metadata...)
+		return nil
+	})
+}
+
+// GenesisAccount is an account added to the genesis by GenesisAccounts.
+type GenesisAccount struct {
+	// Address is the bech32 address of the account.
+	Address string
+
+	// Coins is the balance of the account.
+	Coins sdk.Coins
+
+	// Vesting makes the account a vesting account, if set.
+	Vesting *GenesisVesting
+}
+
+// GenesisVesting is the vesting schedule of a GenesisAccount.
+type GenesisVesting struct {
+	// Coins are the vesting coins of the account, among its coins.
+	Coins sdk.Coins
+
+	// Start is the start of a continuous vesting. If zero, the coins vest all at once at End, with a delayed vesting.
+	Start time.Time
+
+	// End is the end of the vesting.
+	End time.Time
+}
+
+// GenesisAccounts returns a GenesisBuilder modifier adding accounts to the auth module, and their balances to the bank module.
+func GenesisAccounts(accounts ...GenesisAccount) func(*GenesisBuilder) error {
+	return func(b *GenesisBuilder) error {
+		auth, err := ModuleGenesis[authtypes.GenesisState](b, authtypes.ModuleName)
+		if err != nil {
+			return err
+		}
+		bank, err := ModuleGenesis[banktypes.GenesisState](b, banktypes.ModuleName)
+		if err != nil {
+			return err
+		}
+
+		genAccounts, err := authtypes.UnpackAccounts(auth.Accounts)
+		if err != nil {
+			return fmt.Errorf("failed to unpack genesis accounts: %w", err)
+		}
+		for _, acc := range accounts {
+			genAcc, err := acc.genesisAccount()
+			if err != nil {
+				return fmt.Errorf("invalid genesis account %s: %w", acc.Address, err)
+			}
+			genAccounts = append(genAccounts, genAcc)
+
+			bank.Balances = append(bank.Balances, banktypes.Balance{Address: acc.Address, Coins: acc.Coins})
+			if !bank.Supply.IsZero() {
+				bank.Supply = bank.Supply.Add(acc.Coins...)
+			}
+		}
+		if auth.Accounts, err = authtypes.PackAccounts(genAccounts); err != nil {
+			return fmt.Errorf("failed to pack genesis accounts: %w", err)
+		}
+		return nil
+	}
+}
+
+// genesisAccount returns the auth account of acc.
+func (acc GenesisAccount) genesisAccount() (authtypes.GenesisAccount, error) {
+	base := &authtypes.BaseAccount{Address: acc.Address}
+	v := acc.Vesting
+	switch {
+	case v == nil:
+		return base, nil
+	case !v.Coins.IsAllLTE(acc.Coins):
+		return nil, fmt.Errorf("vesting coins %s exceed coins %s", v.Coins, acc.Coins)
+	case v.Start.IsZero():
+		return vestingtypes.NewDelayedVestingAccount(base, v.Coins, v.End.Unix())
+	default:
+		return vestingtypes.NewContinuousVestingAccount(base, v.Coins, v.Start.Unix(), v.End.Unix())
+	}
+}
@@ -0,0 +1,102 @@
+package cosmos
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	sdkmath "cosmossdk.io/math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+)
+
+func testGenesis(t *testing.T) []byte {
+	t.Helper()
+	enc := DefaultEncoding()
+	appState := make(map[string]json.RawMessage)
+	for _, name := range []string{"auth", "bank", "gov"} {
+		appState[name] = basicModule(name).(module.HasGenesisBasics).DefaultGenesis(enc.Codec)
+	}
+	genbz, err := json.Marshal(map[string]any{"chain_id": "test-1", "app_state": appState})
+	require.NoError(t, err)
+	return genbz
+}
+
+func TestGenesisBuilderSet(t *testing.T) {
+	b, err := NewGenesisBuilder(ibc.ChainConfig{}, testGenesis(t))
+	require.NoError(t, err)
+
+	require.NoError(t, b.Set("app_state.gov.params.max_deposit_period", "10s"))
+	require.ErrorContains(t, b.Set("app_state.gov.params.voting_perod", "10s"), `unknown genesis path "app_state.gov.params.voting_perod"`)
+	require.ErrorContains(t, b.Set("app_state.bank.denom_metadata.0.base", "stake"), "unknown genesis path")
+
+	genbz, err := b.Bytes()
+	require.NoError(t, err)
+	require.Equal(t, "10s", gjson.GetBytes(genbz, "app_state.gov.params.max_deposit_period").String())
+	require.False(t, gjson.GetBytes(genbz, "app_state.gov.params.voting_perod").Exists())
+
+	_, err = ModifyGenesisStrict([]GenesisKV{NewGenesisKV("app_state.gov.params.voting_perod", "10s")})(ibc.ChainConfig{}, testGenesis(t))
+	require.ErrorContains(t, err, "unknown genesis path")
+}
+
+func TestGenesisBuilderModules(t *testing.T) {
+	addr := sdk.AccAddress("genesis_builder_test").String()
+	coins := sdk.NewCoins(sdk.NewCoin("stake", sdkmath.NewInt(1_000)))
+	end := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	genbz, err := ModifyGenesisTyped(
+		GenesisVotingPeriod(30*time.Second),
+		GenesisDenomMetadata(banktypes.Metadata{
+			Base:       "stake",
+			Display:    "stake",
+			Name:       "stake",
+			Symbol:     "STAKE",
+			DenomUnits: []*banktypes.DenomUnit{{Denom: "stake"}},
+		}),
+		GenesisAccounts(GenesisAccount{
+			Address: addr,
+			Coins:   coins,
+			Vesting: &GenesisVesting{Coins: coins, End: end},
+		}),
+		func(b *GenesisBuilder) error {
+			// Dotted paths see the changes of the typed states.
+			v, err := b.Get("app_state.gov.params.voting_period")
+			require.NoError(t, err)
+			require.Equal(t, "30s", v)
+			return nil
+		},
+	)(ibc.ChainConfig{}, testGenesis(t))
+	require.NoError(t, err)
+
+	require.Equal(t, "30s", gjson.GetBytes(genbz, "app_state.gov.params.voting_period").String())
+	require.Equal(t, "STAKE", gjson.GetBytes(genbz, "app_state.bank.denom_metadata.0.symbol").String())
+	require.Equal(t, addr, gjson.GetBytes(genbz, "app_state.bank.balances.0.address").String())
+	require.Equal(t, "/cosmos.vesting.v1beta1.DelayedVestingAccount", gjson.GetBytes(genbz, "app_state.auth.accounts.0.@type").String())
+	require.Equal(t, end.Unix(), gjson.GetBytes(genbz, "app_state.auth.accounts.0.base_vesting_account.end_time").Int())
+}
+
+func TestGenesisBuilderValidate(t *testing.T) {
+	_, err := ModifyGenesisTyped(ModifyModuleGenesis("gov", func(gov *govv1.GenesisState) error {
+		zero := time.Duration(0)
+		gov.Params.VotingPeriod = &zero
+		return nil
+	}))(ibc.ChainConfig{}, testGenesis(t))
+	require.ErrorContains(t, err, "invalid genesis state of module gov")
+
+	b, err := NewGenesisBuilder(ibc.ChainConfig{}, testGenesis(t))
+	require.NoError(t, err)
+	_, err = ModuleGenesis[govv1.GenesisState](b, "gov")
+	require.NoError(t, err)
+	_, err = ModuleGenesis[banktypes.GenesisState](b, "gov")
+	require.ErrorContains(t, err, "genesis state of module gov is a *v1.GenesisState")
+	_, err = ModuleGenesis[banktypes.GenesisState](b, "nope")
+	require.ErrorContains(t, err, "unknown genesis path")
+}
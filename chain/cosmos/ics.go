@@ -12,7 +12,6 @@ import (
 	"time"
 
 	"github.com/icza/dyno"
-	"github.com/tidwall/gjson"
 	"go.uber.org/zap"
 	"golang.org/x/mod/semver"
 	"golang.org/x/sync/errgroup"
@@ -26,6 +25,7 @@ import (
 	govv1beta1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1beta1"
 	stakingttypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 
+	"github.com/strangelove-ventures/interchaintest/v8/chain/internal/tendermint"
 	"github.com/strangelove-ventures/interchaintest/v8/dockerutil"
 	"github.com/strangelove-ventures/interchaintest/v8/ibc"
 	"github.com/strangelove-ventures/interchaintest/v8/testreporter"
@@ -161,53 +161,120 @@ func (c *CosmosChain) StartProvider(testName string, ctx context.Context, additi
 		return fmt.Errorf("invalid ICS_SPAWN_TIME_WAIT %s: %w", spawnTimeWait, err)
 	}
 	for _, consumer := range c.Consumers {
-		prop := ccvclient.ConsumerAdditionProposalJSON{
-			Title:         fmt.Sprintf("Addition of %s consumer chain", consumer.cfg.Name),
-			Summary:       "Proposal to add new consumer chain",
-			ChainId:       consumer.cfg.ChainID,
-			InitialHeight: clienttypes.Height{RevisionNumber: clienttypes.ParseChainID(consumer.cfg.ChainID), RevisionHeight: 1},
-			GenesisHash:   []byte("gen_hash"),
-			BinaryHash:    []byte("bin_hash"),
-			SpawnTime:     time.Now().Add(spawnTimeWaitDuration),
-
-			// TODO fetch or default variables
-			BlocksPerDistributionTransmission: 1000,
-			CcvTimeoutPeriod:                  trustingPeriod * 2,
-			TransferTimeoutPeriod:             trustingPeriod,
-			ConsumerRedistributionFraction:    "0.75",
-			HistoricalEntries:                 10000,
-			UnbondingPeriod:                   trustingPeriod,
-			Deposit:                           "100000000" + c.cfg.Denom,
-		}
-
-		height, err := c.Height(ctx)
-		if err != nil {
-			return fmt.Errorf("failed to query provider height before consumer addition proposal: %w", err)
+		spawnTime := time.Now().Add(spawnTimeWaitDuration)
+		switch mode := consumer.cfg.InterchainSecurityConfig.LaunchMode; mode {
+		case ibc.ICSLaunchGov:
+			err = c.proposeConsumerAddition(ctx, proposerKeyName, consumer, spawnTime, trustingPeriod)
+		case ibc.ICSLaunchPermissionless:
+			consumer.consumerID, err = c.ICSCreateConsumer(ctx, proposerKeyName, icsCreateConsumerMsg(consumer.cfg, spawnTime, trustingPeriod))
+		default:
+			err = fmt.Errorf("unknown ICS launch mode %q of consumer %s", mode, consumer.cfg.ChainID)
 		}
-
-		propTx, err := c.ConsumerAdditionProposal(ctx, proposerKeyName, prop)
 		if err != nil {
 			return err
 		}
+		consumer.spawnTime = spawnTime
+	}
 
-		propID, err := strconv.ParseUint(propTx.ProposalID, 10, 64)
-		if err != nil {
-			return fmt.Errorf("failed to parse proposal id: %w", err)
-		}
+	return nil
+}
 
-		if err := c.VoteOnProposalAllValidators(ctx, propID, ProposalVoteYes); err != nil {
-			return err
-		}
+// proposeConsumerAddition launches consumer with a consumer addition proposal voted yes by all validators.
+func (c *CosmosChain) proposeConsumerAddition(ctx context.Context, keyName string, consumer *CosmosChain, spawnTime time.Time, trustingPeriod time.Duration) error {
+	ps := consumer.cfg.InterchainSecurityConfig.PowerShaping
+	topN := ps.TopN
+	switch {
+	case ps.OptIn && topN > 0:
+		return fmt.Errorf("consumer %s cannot be both opt-in and top %d", consumer.cfg.ChainID, topN)
+	case !ps.OptIn && topN == 0:
+		topN = DefaultICSTopN
+	}
+
+	prop := ccvclient.ConsumerAdditionProposalJSON{
+		Title:         fmt.Sprintf("Addition of %s consumer chain", consumer.cfg.Name),
+		Summary:       "Proposal to add new consumer chain",
+		ChainId:       consumer.cfg.ChainID,
+		InitialHeight: clienttypes.Height{RevisionNumber: clienttypes.ParseChainID(consumer.cfg.ChainID), RevisionHeight: 1},
+		GenesisHash:   []byte("gen_hash"),
+		BinaryHash:    []byte("bin_hash"),
+		SpawnTime:     spawnTime,
+
+		// TODO fetch or default variables
+		BlocksPerDistributionTransmission: 1000,
+		CcvTimeoutPeriod:                  trustingPeriod * 2,
+		TransferTimeoutPeriod:             trustingPeriod,
+		ConsumerRedistributionFraction:    "0.75",
+		HistoricalEntries:                 10000,
+		UnbondingPeriod:                   trustingPeriod,
+		Deposit:                           "100000000" + c.cfg.Denom,
+
+		TopN:               topN,
+		ValidatorsPowerCap: ps.ValidatorsPowerCap,
+		ValidatorSetCap:    ps.ValidatorSetCap,
+		Allowlist:          ps.Allowlist,
+		Denylist:           ps.Denylist,
+	}
+
+	height, err := c.Height(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to query provider height before consumer addition proposal: %w", err)
+	}
 
-		_, err = PollForProposalStatus(ctx, c, height, height+10, propID, govv1beta1.StatusPassed)
-		if err != nil {
-			return fmt.Errorf("proposal status did not change to passed in expected number of blocks: %w", err)
-		}
+	propTx, err := c.ConsumerAdditionProposal(ctx, keyName, prop)
+	if err != nil {
+		return err
 	}
 
+	propID, err := strconv.ParseUint(propTx.ProposalID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("failed to parse proposal id: %w", err)
+	}
+
+	if err := c.VoteOnProposalAllValidators(ctx, propID, ProposalVoteYes); err != nil {
+		return err
+	}
+
+	_, err = PollForProposalStatus(ctx, c, height, height+10, propID, govv1beta1.StatusPassed)
+	if err != nil {
+		return fmt.Errorf("proposal status did not change to passed in expected number of blocks: %w", err)
+	}
 	return nil
 }
 
+// icsCreateConsumerMsg returns the create-consumer message of the permissionless consumer of cfg spawning at spawnTime.
+func icsCreateConsumerMsg(cfg ibc.ChainConfig, spawnTime time.Time, trustingPeriod time.Duration) ICSCreateConsumerJSON {
+	ps := cfg.InterchainSecurityConfig.PowerShaping
+	return ICSCreateConsumerJSON{
+		ChainID: cfg.ChainID,
+		Metadata: ICSConsumerMetadataJSON{
+			Name:        cfg.Name,
+			Description: fmt.Sprintf("%s consumer chain", cfg.Name),
+		},
+		InitializationParameters: &ICSConsumerInitializationParametersJSON{
+			InitialHeight: clienttypes.Height{RevisionNumber: clienttypes.ParseChainID(cfg.ChainID), RevisionHeight: 1},
+			GenesisHash:   []byte("gen_hash"),
+			BinaryHash:    []byte("bin_hash"),
+			SpawnTime:     spawnTime,
+
+			UnbondingPeriod:                   trustingPeriod,
+			CcvTimeoutPeriod:                  trustingPeriod * 2,
+			TransferTimeoutPeriod:             trustingPeriod,
+			ConsumerRedistributionFraction:    "0.75",
+			BlocksPerDistributionTransmission: 1000,
+			HistoricalEntries:                 10000,
+		},
+		PowerShapingParameters: &ICSPowerShapingParametersJSON{
+			TopN:               ps.TopN,
+			ValidatorsPowerCap: ps.ValidatorsPowerCap,
+			ValidatorSetCap:    ps.ValidatorSetCap,
+			Allowlist:          ps.Allowlist,
+			Denylist:           ps.Denylist,
+			MinStake:           ps.MinStake,
+			AllowInactiveVals:  ps.AllowInactiveVals,
+		},
+	}
+}
+
 // Bootstraps the consumer chain and starts it from genesis.
 func (c *CosmosChain) StartConsumer(testName string, ctx context.Context, additionalGenesisWallets ...ibc.WalletAmount) error {
 	chainCfg := c.Config()
@@ -247,10 +314,11 @@ func (c *CosmosChain) StartConsumer(testName string, ctx context.Context, additi
 		return err
 	}
 
-	// Copy provider priv val keys to these nodes
+	// Copy provider priv val keys to these nodes, or assign their own, and opt in the provider validators.
 	for i, val := range c.Provider.Validators {
 		eg.Go(func() error {
 			copy := c.cfg.InterchainSecurityConfig.ConsumerCopyProviderKey != nil && c.cfg.InterchainSecurityConfig.ConsumerCopyProviderKey(i)
+			var keyStr string
 			if copy {
 				privVal, err := val.PrivValFileContent(ctx)
 				if err != nil {
@@ -264,9 +332,16 @@ func (c *CosmosChain) StartConsumer(testName string, ctx context.Context, additi
 				if err != nil {
 					return fmt.Errorf("failed to get consumer validator pubkey: %w", err)
 				}
-				keyStr := strings.TrimSpace(string(key))
-				_, err = c.Provider.Validators[i].ExecTx(ctx, valKey, "provider", "assign-consensus-key", c.cfg.ChainID, keyStr)
-				if err != nil {
+				keyStr = strings.TrimSpace(string(key))
+			}
+
+			switch {
+			case c.icsOptIn(i):
+				if err := val.ICSOptIn(ctx, valKey, c.ICSConsumerID(), keyStr); err != nil {
+					return fmt.Errorf("failed to opt in consumer validator: %w", err)
+				}
+			case keyStr != "":
+				if _, err := val.ExecTx(ctx, valKey, "provider", "assign-consensus-key", c.ICSConsumerID(), keyStr); err != nil {
 					return fmt.Errorf("failed to assign consumer validator pubkey: %w", err)
 				}
 			}
@@ -285,13 +360,8 @@ func (c *CosmosChain) StartConsumer(testName string, ctx context.Context, additi
 	}
 
 	// Wait for spawn time
-	proposals, _, err := c.Provider.GetNode().ExecQuery(ctx, "gov", "proposals")
-	if err != nil {
-		return fmt.Errorf("failed to query proposed chains: %w", err)
-	}
-	spawnTime := gjson.GetBytes(proposals, fmt.Sprintf("proposals.#(messages.0.content.chain_id==%q).messages.0.content.spawn_time", c.cfg.ChainID)).Time()
-	c.log.Info("Waiting for chain to spawn", zap.Time("spawn_time", spawnTime), zap.String("chain_id", c.cfg.ChainID))
-	time.Sleep(time.Until(spawnTime))
+	c.log.Info("Waiting for chain to spawn", zap.Time("spawn_time", c.spawnTime), zap.String("chain_id", c.cfg.ChainID))
+	time.Sleep(time.Until(c.spawnTime))
 	if err := testutil.WaitForBlocks(ctx, 2, c.Provider); err != nil {
 		return err
 	}
@@ -309,7 +379,7 @@ func (c *CosmosChain) StartConsumer(testName string, ctx context.Context, additi
 		return err
 	}
 
-	ccvStateMarshaled, _, err := c.Provider.GetNode().ExecQuery(ctx, "provider", "consumer-genesis", c.cfg.ChainID)
+	ccvStateMarshaled, _, err := c.Provider.GetNode().ExecQuery(ctx, "provider", "consumer-genesis", c.ICSConsumerID())
 	if err != nil {
 		return fmt.Errorf("failed to query provider for ccv state: %w", err)
 	}
@@ -459,3 +529,155 @@ func (c *CosmosChain) transformCCVState(ctx context.Context, ccvState []byte, co
 	}
 	return res.Stdout, nil
 }
+
+// DefaultICSTopN is the top N of consumers launched with governance, unless they are opt-in.
+const DefaultICSTopN = 95
+
+// ICSConsumerID returns the id of the consumer on its provider:
+// the consumer id assigned to permissionless consumers, or the chain id.
+func (c *CosmosChain) ICSConsumerID() string {
+	if c.consumerID != "" {
+		return c.consumerID
+	}
+	return c.cfg.ChainID
+}
+
+// icsOptIn reports whether the i-th provider validator opts in to validate the consumer c when it starts.
+func (c *CosmosChain) icsOptIn(i int) bool {
+	icsCfg := c.cfg.InterchainSecurityConfig
+	if icsCfg.ConsumerOptIn != nil {
+		return icsCfg.ConsumerOptIn(i)
+	}
+	return icsCfg.LaunchMode == ibc.ICSLaunchPermissionless || icsCfg.PowerShaping.OptIn
+}
+
+// ICSCreateConsumerJSON is the create-consumer message of a permissionless consumer, from ICS v6.
+type ICSCreateConsumerJSON struct {
+	ChainID                  string                                   `json:"chain_id"`
+	Metadata                 ICSConsumerMetadataJSON                  `json:"metadata"`
+	InitializationParameters *ICSConsumerInitializationParametersJSON `json:"initialization_parameters,omitempty"`
+	PowerShapingParameters   *ICSPowerShapingParametersJSON           `json:"power_shaping_parameters,omitempty"`
+}
+
+// ICSUpdateConsumerJSON is the update-consumer message of the owner of a consumer, from ICS v6.
+// Only the parameters set are updated; the initialization parameters cannot be updated after the consumer launched.
+type ICSUpdateConsumerJSON struct {
+	ConsumerID               string                                   `json:"consumer_id"`
+	OwnerAddress             string                                   `json:"owner_address,omitempty"`
+	Metadata                 *ICSConsumerMetadataJSON                 `json:"metadata,omitempty"`
+	InitializationParameters *ICSConsumerInitializationParametersJSON `json:"initialization_parameters,omitempty"`
+	PowerShapingParameters   *ICSPowerShapingParametersJSON           `json:"power_shaping_parameters,omitempty"`
+}
+
+type ICSConsumerMetadataJSON struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Metadata    string `json:"metadata"`
+}
+
+// ICSConsumerInitializationParametersJSON are the parameters of a consumer chain until its spawn time.
+// A zero SpawnTime keeps the consumer from launching.
+type ICSConsumerInitializationParametersJSON struct {
+	InitialHeight clienttypes.Height `json:"initial_height"`
+	GenesisHash   []byte             `json:"genesis_hash"`
+	BinaryHash    []byte             `json:"binary_hash"`
+	SpawnTime     time.Time          `json:"spawn_time"`
+
+	UnbondingPeriod                   time.Duration `json:"unbonding_period"`
+	CcvTimeoutPeriod                  time.Duration `json:"ccv_timeout_period"`
+	TransferTimeoutPeriod             time.Duration `json:"transfer_timeout_period"`
+	ConsumerRedistributionFraction    string        `json:"consumer_redistribution_fraction"`
+	BlocksPerDistributionTransmission int64         `json:"blocks_per_distribution_transmission"`
+	HistoricalEntries                 int64         `json:"historical_entries"`
+	DistributionTransmissionChannel   string        `json:"distribution_transmission_channel"`
+}
+
+// ICSPowerShapingParametersJSON are the power shaping parameters of a consumer chain, see ibc.ICSPowerShaping.
+type ICSPowerShapingParametersJSON struct {
+	TopN               uint32   `json:"top_N"`
+	ValidatorsPowerCap uint32   `json:"validators_power_cap"`
+	ValidatorSetCap    uint32   `json:"validator_set_cap"`
+	Allowlist          []string `json:"allowlist"`
+	Denylist           []string `json:"denylist"`
+	MinStake           uint64   `json:"min_stake"`
+	AllowInactiveVals  bool     `json:"allow_inactive_vals"`
+}
+
+// ICSCreateConsumer creates a permissionless consumer owned by keyName, from ICS v6.
+func (tn *ChainNode) ICSCreateConsumer(ctx context.Context, keyName string, msg ICSCreateConsumerJSON) (string, error) {
+	filePath, err := tn.writeTxJSON(ctx, msg)
+	if err != nil {
+		return "", err
+	}
+	return tn.ExecTx(ctx, keyName, "provider", "create-consumer", filePath)
+}
+
+// ICSUpdateConsumer updates a consumer owned by keyName, from ICS v6.
+func (tn *ChainNode) ICSUpdateConsumer(ctx context.Context, keyName string, msg ICSUpdateConsumerJSON) error {
+	filePath, err := tn.writeTxJSON(ctx, msg)
+	if err != nil {
+		return err
+	}
+	_, err = tn.ExecTx(ctx, keyName, "provider", "update-consumer", filePath)
+	return err
+}
+
+// ICSOptIn opts in the validator of keyName to validate the consumer consumerID, see CosmosChain.ICSConsumerID,
+// with the consensus key consumerKey, a JSON encoded public key, or its provider consensus key if empty.
+func (tn *ChainNode) ICSOptIn(ctx context.Context, keyName, consumerID, consumerKey string) error {
+	command := []string{"provider", "opt-in", consumerID}
+	if consumerKey != "" {
+		command = append(command, consumerKey)
+	}
+	_, err := tn.ExecTx(ctx, keyName, command...)
+	return err
+}
+
+// ICSOptOut opts out the validator of keyName from validating the consumer consumerID, see CosmosChain.ICSConsumerID.
+func (tn *ChainNode) ICSOptOut(ctx context.Context, keyName, consumerID string) error {
+	_, err := tn.ExecTx(ctx, keyName, "provider", "opt-out", consumerID)
+	return err
+}
+
+// writeTxJSON writes v as JSON to a new file of the home directory, and returns its path, e.g. for a transaction taking a JSON file.
+func (tn *ChainNode) writeTxJSON(ctx context.Context, v any) (string, error) {
+	bz, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	fileName := "tx_" + dockerutil.RandLowerCaseLetterString(4) + ".json"
+	if err := tn.WriteFile(ctx, bz, fileName); err != nil {
+		return "", fmt.Errorf("failure writing tx json: %w", err)
+	}
+	return path.Join(tn.HomeDir(), fileName), nil
+}
+
+// ICSCreateConsumer creates a permissionless consumer owned by keyName, and returns its consumer id, from ICS v6.
+// The consumer launches at its spawn time if validators opted in to validate it, see ChainNode.ICSOptIn.
+func (c *CosmosChain) ICSCreateConsumer(ctx context.Context, keyName string, msg ICSCreateConsumerJSON) (string, error) {
+	if msg.PowerShapingParameters != nil && msg.PowerShapingParameters.TopN > 0 {
+		return "", fmt.Errorf("permissionless consumer %s cannot be top %d", msg.ChainID, msg.PowerShapingParameters.TopN)
+	}
+	txHash, err := c.GetFullNode().ICSCreateConsumer(ctx, keyName, msg)
+	if err != nil {
+		return "", fmt.Errorf("failed to create consumer %s: %w", msg.ChainID, err)
+	}
+	txResp, err := c.GetTransaction(txHash)
+	if err != nil {
+		return "", fmt.Errorf("failed to get transaction %s: %w", txHash, err)
+	}
+	consumerID, ok := tendermint.AttributeValue(txResp.Events, "create_consumer", "consumer_id")
+	if !ok {
+		return "", fmt.Errorf("consumer id of %s not found in events of transaction %s", msg.ChainID, txHash)
+	}
+	return consumerID, nil
+}
+
+// ICSUpdateConsumer updates a consumer owned by keyName, e.g. its power shaping parameters, from ICS v6.
+func (c *CosmosChain) ICSUpdateConsumer(ctx context.Context, keyName string, msg ICSUpdateConsumerJSON) error {
+	if err := c.GetFullNode().ICSUpdateConsumer(ctx, keyName, msg); err != nil {
+		return fmt.Errorf("failed to update consumer %s: %w", msg.ConsumerID, err)
+	}
+	return nil
+}
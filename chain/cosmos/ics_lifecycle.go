@@ -0,0 +1,326 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	govv1beta1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1beta1"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	conntypes "github.com/cosmos/ibc-go/v8/modules/core/03-connection/types"
+	chantypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+	consumertypes "github.com/cosmos/interchain-security/v5/x/ccv/consumer/types"
+	ccvclient "github.com/cosmos/interchain-security/v5/x/ccv/provider/client"
+	providertypes "github.com/cosmos/interchain-security/v5/x/ccv/provider/types"
+
+	"github.com/strangelove-ventures/interchaintest/v8/dockerutil"
+	"github.com/strangelove-ventures/interchaintest/v8/testutil"
+)
+
+// icsProviderPort is the port of the CCV channels on the provider chain.
+const icsProviderPort = "provider"
+
+// ICSAssignConsumerKey assigns the consensus key consumerKey, a JSON encoded public key,
+// to the validator of keyName on the consumer chain consumerChainID.
+func (tn *ChainNode) ICSAssignConsumerKey(ctx context.Context, keyName, consumerChainID, consumerKey string) error {
+	if tn.UsesGRPCTx() {
+		return tn.broadcastMsgsFrom(ctx, keyName, func(addr string) ([]sdk.Msg, error) {
+			valoper, err := tn.KeyBech32(ctx, keyName, "val")
+			if err != nil {
+				return nil, err
+			}
+			return []sdk.Msg{&providertypes.MsgAssignConsumerKey{
+				ChainId:      consumerChainID,
+				ProviderAddr: valoper,
+				ConsumerKey:  consumerKey,
+				Signer:       addr,
+			}}, nil
+		})
+	}
+
+	_, err := tn.ExecTx(ctx, keyName, "provider", "assign-consensus-key", consumerChainID, consumerKey)
+	return err
+}
+
+func (tn *ChainNode) ConsumerRemovalProposal(ctx context.Context, keyName string, prop ccvclient.ConsumerRemovalProposalJSON) (string, error) {
+	if tn.UsesGRPCTx() {
+		content := providertypes.NewConsumerRemovalProposal(prop.Title, prop.Summary, prop.ChainId, prop.StopTime)
+		return tn.broadcastLegacyProposal(ctx, keyName, content, prop.Deposit)
+	}
+
+	propBz, err := json.Marshal(prop)
+	if err != nil {
+		return "", err
+	}
+
+	fileName := "proposal_" + dockerutil.RandLowerCaseLetterString(4) + ".json"
+
+	fw := dockerutil.NewFileWriter(tn.logger(), tn.DockerClient, tn.TestName)
+	if err := fw.WriteFile(ctx, tn.VolumeName, fileName, propBz); err != nil {
+		return "", fmt.Errorf("failure writing proposal json: %w", err)
+	}
+
+	filePath := filepath.Join(tn.HomeDir(), fileName)
+
+	return tn.ExecTx(ctx, keyName,
+		"gov", "submit-legacy-proposal", "consumer-removal", filePath,
+		"--gas", "auto",
+	)
+}
+
+// ConsumerRemovalProposal submits a legacy governance proposal to remove a consumer from the chain.
+func (c *CosmosChain) ConsumerRemovalProposal(ctx context.Context, keyName string, prop ccvclient.ConsumerRemovalProposalJSON) (tx TxProposal, _ error) {
+	txHash, err := c.GetFullNode().ConsumerRemovalProposal(ctx, keyName, prop)
+	if err != nil {
+		return tx, fmt.Errorf("failed to submit consumer removal proposal: %w", err)
+	}
+	return c.txProposal(txHash)
+}
+
+// ICSAssignConsumerKey assigns a new consensus key to the i-th validator of the provider on consumer,
+// restarts the i-th validator of consumer signing with it, and returns its hex consensus address
+// once it is in the validator set of consumer.
+// A relayer must relay the validator set change packets of the provider, within maxBlocks blocks of consumer.
+func (c *CosmosChain) ICSAssignConsumerKey(ctx context.Context, consumer *CosmosChain, i, maxBlocks int) (string, error) {
+	val := consumer.Validators[i]
+	pubKey, privVal, err := val.NewConsensusKey(ctx)
+	if err != nil {
+		return "", err
+	}
+	if err := c.Validators[i].ICSAssignConsumerKey(ctx, valKey, consumer.ICSConsumerID(), pubKey); err != nil {
+		return "", fmt.Errorf("failed to assign consumer key: %w", err)
+	}
+	if err := val.restartWithPrivVal(ctx, privVal); err != nil {
+		return "", err
+	}
+
+	consAddr, err := val.ConsensusAddress(ctx)
+	if err != nil {
+		return "", err
+	}
+	if err := consumer.WaitForCometValidator(ctx, consAddr, true, maxBlocks); err != nil {
+		return consAddr, fmt.Errorf("assigned consumer key not in the validator set of %s: %w", consumer.cfg.ChainID, err)
+	}
+	return consAddr, nil
+}
+
+// ICSRemoveConsumer removes consumer from the provider with a consumer removal proposal stopping it after stopDelay,
+// voted yes by all validators, and waits at most maxBlocks blocks for the provider to stop the consumer
+// and close its CCV channel. A relayer completes the closing handshake on the consumer.
+func (c *CosmosChain) ICSRemoveConsumer(ctx context.Context, consumer *CosmosChain, stopDelay time.Duration, maxBlocks int) error {
+	chainID := consumer.cfg.ChainID
+	chains, err := c.ICSQueryConsumerChains(ctx)
+	if err != nil {
+		return err
+	}
+	i := slices.IndexFunc(chains, func(ch *providertypes.Chain) bool { return ch.ChainId == chainID })
+	if i < 0 {
+		return fmt.Errorf("%s is not a consumer of %s", chainID, c.cfg.ChainID)
+	}
+	clientID := chains[i].ClientId
+
+	params, err := c.GovQueryParams(ctx, "deposit")
+	if err != nil {
+		return err
+	}
+	height, err := c.Height(ctx)
+	if err != nil {
+		return err
+	}
+	propTx, err := c.ConsumerRemovalProposal(ctx, valKey, ccvclient.ConsumerRemovalProposalJSON{
+		Title:    fmt.Sprintf("Removal of %s consumer chain", chainID),
+		Summary:  "Proposal to remove consumer chain",
+		ChainId:  chainID,
+		StopTime: time.Now().Add(stopDelay),
+		Deposit:  sdk.Coins(params.MinDeposit).String(),
+	})
+	if err != nil {
+		return err
+	}
+	propID, err := strconv.ParseUint(propTx.ProposalID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("failed to parse proposal id: %w", err)
+	}
+	if err := c.VoteOnProposalAllValidators(ctx, propID, ProposalVoteYes); err != nil {
+		return err
+	}
+	if _, err := PollForProposalStatus(ctx, c, height, height+int64(maxBlocks), propID, govv1beta1.StatusPassed); err != nil {
+		return fmt.Errorf("proposal status did not change to passed in expected number of blocks: %w", err)
+	}
+
+	return testutil.WaitForBlocksUtil(maxBlocks, func(int) error {
+		if err := testutil.WaitForBlocks(ctx, 1, c); err != nil {
+			return err
+		}
+		chains, err := c.ICSQueryConsumerChains(ctx)
+		if err != nil {
+			return err
+		}
+		if slices.ContainsFunc(chains, func(ch *providertypes.Chain) bool { return ch.ChainId == chainID }) {
+			return fmt.Errorf("%s is still a consumer", chainID)
+		}
+		channels, err := c.icsChannels(ctx, clientID)
+		if err != nil {
+			return err
+		}
+		for _, ch := range channels {
+			if ch.State != chantypes.CLOSED {
+				return fmt.Errorf("CCV channel %s is %s", ch.ChannelId, ch.State)
+			}
+		}
+		return nil
+	})
+}
+
+// icsChannels returns the CCV channels of the provider over the connections of the client clientID.
+func (c *CosmosChain) icsChannels(ctx context.Context, clientID string) ([]*chantypes.IdentifiedChannel, error) {
+	conns, err := conntypes.NewQueryClient(c.GetNode().GrpcConn).ClientConnections(ctx, &conntypes.QueryClientConnectionsRequest{ClientId: clientID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query connections of client %s: %w", clientID, err)
+	}
+
+	var channels []*chantypes.IdentifiedChannel
+	for _, connID := range conns.ConnectionPaths {
+		res, err := chantypes.NewQueryClient(c.GetNode().GrpcConn).ConnectionChannels(ctx, &chantypes.QueryConnectionChannelsRequest{Connection: connID})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query channels of connection %s: %w", connID, err)
+		}
+		for _, ch := range res.Channels {
+			if ch.PortId == icsProviderPort {
+				channels = append(channels, ch)
+			}
+		}
+	}
+	return channels, nil
+}
+
+// ICSWaitForVSCMatured waits at most maxBlocks blocks until the consumer chainID acknowledged the maturity
+// of all validator set changes sent by the provider, which releases the unbonding operations they hold.
+// A relayer must relay the packets of the CCV channel both ways.
+func (c *CosmosChain) ICSWaitForVSCMatured(ctx context.Context, chainID string, maxBlocks int) error {
+	return testutil.WaitForBlocksUtil(maxBlocks, func(int) error {
+		vsc, err := c.ICSQueryOldestUnconfirmedVSC(ctx, chainID)
+		if err != nil {
+			return err
+		}
+		if vsc == nil {
+			return nil
+		}
+		if err := testutil.WaitForBlocks(ctx, 1, c); err != nil {
+			return err
+		}
+		return fmt.Errorf("validator set change %d sent at %s not matured", vsc.VscId, vsc.Timestamp)
+	})
+}
+
+// ICSUnbondingOnHold reports whether an entry of the unbonding delegation of delegator from validator
+// is held by a consumer until it acknowledges the maturity of the validator set change of the unbonding.
+func (c *CosmosChain) ICSUnbondingOnHold(ctx context.Context, delegator, validator string) (bool, error) {
+	ubd, err := c.StakingQueryUnbondingDelegation(ctx, delegator, validator)
+	if err != nil {
+		return false, err
+	}
+	return slices.ContainsFunc(ubd.Entries, func(e stakingtypes.UnbondingDelegationEntry) bool {
+		return e.UnbondingOnHoldRefCount > 0
+	}), nil
+}
+
+// ICSConsumerRewardsPool returns the balance of the consumer rewards pool of the provider,
+// which receives the rewards transmitted by consumers before they are distributed to the validators.
+func (c *CosmosChain) ICSConsumerRewardsPool(ctx context.Context) (sdk.Coins, error) {
+	acc, err := c.AuthQueryModuleAccount(ctx, providertypes.ConsumerRewardsPool)
+	if err != nil {
+		return nil, err
+	}
+	return c.BankQueryAllBalances(ctx, acc.BaseAccount.Address)
+}
+
+// ICSWaitForConsumerRewards waits at most maxBlocks blocks until the consumer rewards pool of the provider
+// holds more than before, and returns the rewards received.
+// Rewards are only distributed to validators for denoms registered with the provider, see ICSQueryConsumerRewardDenoms.
+// A relayer must relay the transfers of the consumer, every BlocksPerDistributionTransmission blocks of the consumer.
+func (c *CosmosChain) ICSWaitForConsumerRewards(ctx context.Context, maxBlocks int) (sdk.Coins, error) {
+	before, err := c.ICSConsumerRewardsPool(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var rewards sdk.Coins
+	err = testutil.WaitForBlocksUtil(maxBlocks, func(int) error {
+		if err := testutil.WaitForBlocks(ctx, 1, c); err != nil {
+			return err
+		}
+		after, err := c.ICSConsumerRewardsPool(ctx)
+		if err != nil {
+			return err
+		}
+		var hasNeg bool
+		rewards, hasNeg = after.SafeSub(before...)
+		if hasNeg || rewards.IsZero() {
+			return fmt.Errorf("no consumer rewards received, pool holds %s", after)
+		}
+		return nil
+	})
+	return rewards, err
+}
+
+// ICSQueryConsumerChains returns the consumer chains of the provider.
+func (c *CosmosChain) ICSQueryConsumerChains(ctx context.Context) ([]*providertypes.Chain, error) {
+	res, err := providertypes.NewQueryClient(c.GetNode().GrpcConn).QueryConsumerChains(ctx, &providertypes.QueryConsumerChainsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return res.Chains, nil
+}
+
+// ICSQueryValidatorConsumerAddr returns the consensus address on the consumer chainID of the provider validator
+// with the bech32 consensus address providerValcons, as assigned with ICSAssignConsumerKey.
+func (c *CosmosChain) ICSQueryValidatorConsumerAddr(ctx context.Context, chainID, providerValcons string) (string, error) {
+	res, err := providertypes.NewQueryClient(c.GetNode().GrpcConn).QueryValidatorConsumerAddr(ctx, &providertypes.QueryValidatorConsumerAddrRequest{
+		ChainId:         chainID,
+		ProviderAddress: providerValcons,
+	})
+	if err != nil {
+		return "", err
+	}
+	return res.ConsumerAddress, nil
+}
+
+// ICSQueryOldestUnconfirmedVSC returns the oldest validator set change sent to the consumer chainID whose maturity
+// it did not acknowledge yet, or nil if it acknowledged all of them.
+func (c *CosmosChain) ICSQueryOldestUnconfirmedVSC(ctx context.Context, chainID string) (*providertypes.VscSendTimestamp, error) {
+	res, err := providertypes.NewQueryClient(c.GetNode().GrpcConn).QueryOldestUnconfirmedVsc(ctx, &providertypes.QueryOldestUnconfirmedVscRequest{ChainId: chainID})
+	if status.Code(err) == codes.NotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &res.VscSendTimestamp, nil
+}
+
+// ICSQueryConsumerRewardDenoms returns the denoms of consumer rewards distributed by the provider.
+func (c *CosmosChain) ICSQueryConsumerRewardDenoms(ctx context.Context) ([]string, error) {
+	res, err := providertypes.NewQueryClient(c.GetNode().GrpcConn).QueryRegisteredConsumerRewardDenoms(ctx, &providertypes.QueryRegisteredConsumerRewardDenomsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return res.Denoms, nil
+}
+
+// ICSQueryNextFeeDistribution returns the estimate of the next transmission of rewards of the consumer to its provider.
+func (c *CosmosChain) ICSQueryNextFeeDistribution(ctx context.Context) (*consumertypes.NextFeeDistributionEstimate, error) {
+	res, err := consumertypes.NewQueryClient(c.GetNode().GrpcConn).QueryNextFeeDistribution(ctx, &consumertypes.QueryNextFeeDistributionEstimateRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return res.Data, nil
+}
@@ -0,0 +1,59 @@
+package cosmos
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tidwall/gjson"
+
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+)
+
+func TestICSOptIn(t *testing.T) {
+	consumer := func(icsCfg ibc.ICSConfig) *CosmosChain {
+		return &CosmosChain{cfg: ibc.ChainConfig{ChainID: "consumer-1", InterchainSecurityConfig: icsCfg}}
+	}
+
+	require.False(t, consumer(ibc.ICSConfig{}).icsOptIn(0))
+	require.True(t, consumer(ibc.ICSConfig{PowerShaping: ibc.ICSPowerShaping{OptIn: true}}).icsOptIn(0))
+	require.True(t, consumer(ibc.ICSConfig{LaunchMode: ibc.ICSLaunchPermissionless}).icsOptIn(0))
+
+	c := consumer(ibc.ICSConfig{
+		LaunchMode:    ibc.ICSLaunchPermissionless,
+		ConsumerOptIn: func(i int) bool { return i == 1 },
+	})
+	require.False(t, c.icsOptIn(0))
+	require.True(t, c.icsOptIn(1))
+
+	require.Equal(t, "consumer-1", c.ICSConsumerID())
+	c.consumerID = "0"
+	require.Equal(t, "0", c.ICSConsumerID())
+}
+
+func TestICSCreateConsumerMsg(t *testing.T) {
+	spawnTime := time.Date(2024, 8, 29, 12, 0, 0, 0, time.UTC)
+	msg := icsCreateConsumerMsg(ibc.ChainConfig{
+		Name:    "ics-consumer",
+		ChainID: "consumer-1",
+		InterchainSecurityConfig: ibc.ICSConfig{
+			LaunchMode:   ibc.ICSLaunchPermissionless,
+			PowerShaping: ibc.ICSPowerShaping{ValidatorsPowerCap: 10, Allowlist: []string{"cosmosvalcons1"}},
+		},
+	}, spawnTime, time.Hour)
+
+	bz, err := json.Marshal(msg)
+	require.NoError(t, err)
+
+	require.Equal(t, "consumer-1", gjson.GetBytes(bz, "chain_id").String())
+	require.Equal(t, "ics-consumer", gjson.GetBytes(bz, "metadata.name").String())
+	require.EqualValues(t, 1, gjson.GetBytes(bz, "initialization_parameters.initial_height.revision_number").Int())
+	require.EqualValues(t, 1, gjson.GetBytes(bz, "initialization_parameters.initial_height.revision_height").Int())
+	require.Equal(t, spawnTime, gjson.GetBytes(bz, "initialization_parameters.spawn_time").Time())
+	require.Equal(t, time.Hour.Nanoseconds(), gjson.GetBytes(bz, "initialization_parameters.unbonding_period").Int())
+	require.Equal(t, 2*time.Hour.Nanoseconds(), gjson.GetBytes(bz, "initialization_parameters.ccv_timeout_period").Int())
+	require.EqualValues(t, 0, gjson.GetBytes(bz, "power_shaping_parameters.top_N").Int())
+	require.EqualValues(t, 10, gjson.GetBytes(bz, "power_shaping_parameters.validators_power_cap").Int())
+	require.Equal(t, "cosmosvalcons1", gjson.GetBytes(bz, "power_shaping_parameters.allowlist.0").String())
+}
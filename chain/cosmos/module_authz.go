@@ -4,10 +4,14 @@ import (
 	"context"
 	"fmt"
 	"path"
+	"strconv"
 	"strings"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/authz"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 
 	"github.com/strangelove-ventures/interchaintest/v8/ibc"
 )
@@ -38,6 +42,14 @@ func (tn *ChainNode) AuthzGrant(ctx context.Context, granter ibc.Wallet, grantee
 		extraFlags[msgTypeIndex+1] = PrefixMsgTypeIfRequired(extraFlags[msgTypeIndex+1])
 	}
 
+	if tn.UsesGRPCTx() {
+		txHash, err := tn.broadcastAuthzGrant(ctx, granter, grantee, authType, extraFlags)
+		if err != nil {
+			return nil, err
+		}
+		return tn.TxHashToResponse(ctx, txHash)
+	}
+
 	cmd = append(cmd, extraFlags...)
 
 	txHash, err := tn.ExecTx(ctx, granter.KeyName(),
@@ -50,8 +62,93 @@ func (tn *ChainNode) AuthzGrant(ctx context.Context, granter ibc.Wallet, grantee
 	return tn.TxHashToResponse(ctx, txHash)
 }
 
+// broadcastAuthzGrant is the gRPC counterpart of the authz grant command run by AuthzGrant.
+// It supports the flags --msg-type, --spend-limit, --allow-list, --allowed-validators, --deny-validators and --expiration of the command.
+func (tn *ChainNode) broadcastAuthzGrant(ctx context.Context, granter ibc.Wallet, grantee, authType string, extraFlags []string) (string, error) {
+	flags, err := parseTxFlags(extraFlags, "msg-type", "spend-limit", "allow-list", "allowed-validators", "deny-validators", "expiration")
+	if err != nil {
+		return "", err
+	}
+
+	var authorization authz.Authorization
+	switch authType {
+	case "send":
+		spendLimit, err := sdk.ParseCoinsNormalized(flags["spend-limit"])
+		if err != nil {
+			return "", err
+		}
+		send := &banktypes.SendAuthorization{SpendLimit: spendLimit}
+		if allowList := flags["allow-list"]; allowList != "" {
+			send.AllowList = strings.Split(allowList, ",")
+		}
+		authorization = send
+	case "generic":
+		authorization = authz.NewGenericAuthorization(flags["msg-type"])
+	default:
+		stake := &stakingtypes.StakeAuthorization{AuthorizationType: map[string]stakingtypes.AuthorizationType{
+			"delegate":   stakingtypes.AuthorizationType_AUTHORIZATION_TYPE_DELEGATE,
+			"unbond":     stakingtypes.AuthorizationType_AUTHORIZATION_TYPE_UNDELEGATE,
+			"redelegate": stakingtypes.AuthorizationType_AUTHORIZATION_TYPE_REDELEGATE,
+		}[authType]}
+		if limit := flags["spend-limit"]; limit != "" {
+			maxTokens, err := sdk.ParseCoinNormalized(limit)
+			if err != nil {
+				return "", err
+			}
+			stake.MaxTokens = &maxTokens
+		}
+		allowed, denied := flags["allowed-validators"], flags["deny-validators"]
+		switch {
+		case allowed != "" && denied != "":
+			return "", fmt.Errorf("cannot set both allowed and denied validators")
+		case allowed != "":
+			stake.Validators = &stakingtypes.StakeAuthorization_AllowList{
+				AllowList: &stakingtypes.StakeAuthorization_Validators{Address: strings.Split(allowed, ",")},
+			}
+		case denied != "":
+			stake.Validators = &stakingtypes.StakeAuthorization_DenyList{
+				DenyList: &stakingtypes.StakeAuthorization_Validators{Address: strings.Split(denied, ",")},
+			}
+		default:
+			return "", fmt.Errorf("allowed or denied validators are required")
+		}
+		authorization = stake
+	}
+
+	var expiration *time.Time
+	if v, ok := flags["expiration"]; ok {
+		unix, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("invalid expiration %q: %w", v, err)
+		}
+		t := time.Unix(unix, 0)
+		expiration = &t
+	}
+	grant, err := authz.NewGrant(time.Now(), authorization, expiration)
+	if err != nil {
+		return "", err
+	}
+
+	granterAddr, err := tn.KeyAddress(ctx, granter.KeyName())
+	if err != nil {
+		return "", err
+	}
+	res, err := tn.broadcastTx(ctx, granter.KeyName(), flags, &authz.MsgGrant{Granter: granterAddr, Grantee: grantee, Grant: grant})
+	return res.TxHash, err
+}
+
 // AuthzExec executes an authz MsgExec transaction with a single nested message.
+// The nested message is generated by nestedMsgCmd, a tx subcommand of the chain binary, even with ibc.TxModeGRPC.
+// Use AuthzExecMsgs to not depend on the CLI of the chain binary.
 func (tn *ChainNode) AuthzExec(ctx context.Context, grantee ibc.Wallet, nestedMsgCmd []string) (*sdk.TxResponse, error) {
+	if tn.UsesGRPCTx() {
+		msgs, err := generateMsgs(ctx, tn, nestedMsgCmd)
+		if err != nil {
+			return nil, err
+		}
+		return tn.AuthzExecMsgs(ctx, grantee, msgs...)
+	}
+
 	fileName := "authz.json"
 	if err := createAuthzJSON(ctx, tn, fileName, nestedMsgCmd); err != nil {
 		return nil, err
@@ -67,10 +164,38 @@ func (tn *ChainNode) AuthzExec(ctx context.Context, grantee ibc.Wallet, nestedMs
 	return tn.TxHashToResponse(ctx, txHash)
 }
 
+// AuthzExecMsgs executes an authz MsgExec transaction with the nested msgs, signed in Go and broadcast with BroadcastMsgs.
+func (tn *ChainNode) AuthzExecMsgs(ctx context.Context, grantee ibc.Wallet, msgs ...sdk.Msg) (*sdk.TxResponse, error) {
+	granteeAddr, err := tn.KeyAddress(ctx, grantee.KeyName())
+	if err != nil {
+		return nil, err
+	}
+	msg := authz.NewMsgExec(sdk.AccAddress{}, msgs)
+	msg.Grantee = granteeAddr
+
+	res, err := tn.broadcastTx(ctx, grantee.KeyName(), nil, &msg)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
 // AuthzRevoke revokes a message as a permission to an account.
 func (tn *ChainNode) AuthzRevoke(ctx context.Context, granter ibc.Wallet, grantee string, msgType string) (*sdk.TxResponse, error) {
 	msgType = PrefixMsgTypeIfRequired(msgType)
 
+	if tn.UsesGRPCTx() {
+		granterAddr, err := tn.KeyAddress(ctx, granter.KeyName())
+		if err != nil {
+			return nil, err
+		}
+		res, err := tn.broadcastTx(ctx, granter.KeyName(), nil, &authz.MsgRevoke{Granter: granterAddr, Grantee: grantee, MsgTypeUrl: msgType})
+		if err != nil {
+			return nil, err
+		}
+		return &res, nil
+	}
+
 	txHash, err := tn.ExecTx(ctx, granter.KeyName(),
 		"authz", "revoke", grantee, msgType,
 	)
@@ -124,6 +249,24 @@ func createAuthzJSON(ctx context.Context, node *ChainNode, filePath string, genM
 	return node.WriteFile(ctx, res, filePath)
 }
 
+// generateMsgs returns the msgs of the transaction generated by genMsgCmd, a tx subcommand of the chain binary.
+func generateMsgs(ctx context.Context, node *ChainNode, genMsgCmd []string) ([]sdk.Msg, error) {
+	if !strings.Contains(strings.Join(genMsgCmd, " "), "--generate-only") {
+		genMsgCmd = append(genMsgCmd, "--generate-only")
+	}
+
+	res, resErr, err := node.Exec(ctx, genMsgCmd, node.Chain.Config().Env)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate msg: %s: %w", resErr, err)
+	}
+
+	tx, err := node.Chain.Config().EncodingConfig.TxConfig.TxJSONDecoder()(res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode generated msg: %w", err)
+	}
+	return tx.GetMsgs(), nil
+}
+
 func PrefixMsgTypeIfRequired(msgType string) string {
 	if !strings.HasPrefix(msgType, "/") {
 		msgType = "/" + msgType
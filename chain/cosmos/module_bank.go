@@ -14,6 +14,11 @@ import (
 
 // BankSend sends tokens from one account to another.
 func (tn *ChainNode) BankSend(ctx context.Context, keyName string, amount ibc.WalletAmount) error {
+	if tn.UsesGRPCTx() {
+		_, err := tn.BankSendWithNote(ctx, keyName, amount, "")
+		return err
+	}
+
 	_, err := tn.ExecTx(ctx,
 		keyName, "bank", "send", keyName,
 		amount.Address, fmt.Sprintf("%s%s", amount.Amount.String(), amount.Denom),
@@ -23,6 +28,18 @@ func (tn *ChainNode) BankSend(ctx context.Context, keyName string, amount ibc.Wa
 
 // BankSend sends tokens from one account to another.
 func (tn *ChainNode) BankSendWithNote(ctx context.Context, keyName string, amount ibc.WalletAmount, note string) (string, error) {
+	if tn.UsesGRPCTx() {
+		from, err := tn.KeyAddress(ctx, keyName)
+		if err != nil {
+			return "", err
+		}
+		return tn.BroadcastMsgsWithMemo(ctx, keyName, note, &banktypes.MsgSend{
+			FromAddress: from,
+			ToAddress:   amount.Address,
+			Amount:      types.NewCoins(types.NewCoin(amount.Denom, amount.Amount)),
+		})
+	}
+
 	return tn.ExecTx(ctx, keyName, "bank", "send", keyName, amount.Address,
 		fmt.Sprintf("%s%s", amount.Amount.String(), amount.Denom), "--note", note)
 }
@@ -34,6 +51,24 @@ func (tn *ChainNode) SendFunds(ctx context.Context, keyName string, amount ibc.W
 
 // BankMultiSend sends an amount of token from one account to multiple accounts.
 func (tn *ChainNode) BankMultiSend(ctx context.Context, keyName string, addresses []string, amount sdkmath.Int, denom string) error {
+	if tn.UsesGRPCTx() {
+		from, err := tn.KeyAddress(ctx, keyName)
+		if err != nil {
+			return err
+		}
+		coins := types.NewCoins(types.NewCoin(denom, amount))
+		outputs := make([]banktypes.Output, len(addresses))
+		for i, addr := range addresses {
+			outputs[i] = banktypes.Output{Address: addr, Coins: coins}
+		}
+		total := types.NewCoins(types.NewCoin(denom, amount.MulRaw(int64(len(addresses)))))
+		_, err = tn.BroadcastMsgs(ctx, keyName, &banktypes.MsgMultiSend{
+			Inputs:  []banktypes.Input{{Address: from, Coins: total}},
+			Outputs: outputs,
+		})
+		return err
+	}
+
 	cmd := append([]string{"bank", "multi-send", keyName}, addresses...)
 	cmd = append(cmd, fmt.Sprintf("%s%s", amount, denom))
 
@@ -0,0 +1,150 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/json"
+
+	circuittypes "cosmossdk.io/x/circuit/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// CircuitAuthorize grants grantee the permission to trip and reset circuit breakers.
+// The message type URLs in limitTypeURLs only apply to circuittypes.Permissions_LEVEL_SOME_MSGS,
+// and are prefixed with a "/" if required.
+func (tn *ChainNode) CircuitAuthorize(ctx context.Context, keyName, grantee string, level circuittypes.Permissions_Level, limitTypeURLs []string) error {
+	limitTypeURLs = prefixMsgTypes(limitTypeURLs)
+
+	if tn.UsesGRPCTx() {
+		return tn.broadcastMsgsFrom(ctx, keyName, func(addr string) ([]sdk.Msg, error) {
+			return []sdk.Msg{&circuittypes.MsgAuthorizeCircuitBreaker{
+				Granter: addr,
+				Grantee: grantee,
+				Permissions: &circuittypes.Permissions{
+					Level:         level,
+					LimitTypeUrls: limitTypeURLs,
+				},
+			}}, nil
+		})
+	}
+
+	permissions, err := json.Marshal(struct {
+		Level         int32    `json:"level"`
+		LimitTypeURLs []string `json:"limit_type_urls,omitempty"`
+	}{int32(level), limitTypeURLs})
+	if err != nil {
+		return err
+	}
+
+	_, err = tn.ExecTx(ctx, keyName, "circuit", "authorize", grantee, string(permissions))
+	return err
+}
+
+// CircuitDisable trips the circuit breaker of the given message types, so that they can no longer be executed.
+func (tn *ChainNode) CircuitDisable(ctx context.Context, keyName string, typeURLs ...string) error {
+	typeURLs = prefixMsgTypes(typeURLs)
+
+	if tn.UsesGRPCTx() {
+		return tn.broadcastMsgsFrom(ctx, keyName, func(addr string) ([]sdk.Msg, error) {
+			return []sdk.Msg{&circuittypes.MsgTripCircuitBreaker{Authority: addr, MsgTypeUrls: typeURLs}}, nil
+		})
+	}
+
+	_, err := tn.ExecTx(ctx, keyName, append([]string{"circuit", "disable"}, typeURLs...)...)
+	return err
+}
+
+// CircuitReset resets the circuit breaker of the given message types, so that they can be executed again.
+func (tn *ChainNode) CircuitReset(ctx context.Context, keyName string, typeURLs ...string) error {
+	typeURLs = prefixMsgTypes(typeURLs)
+
+	if tn.UsesGRPCTx() {
+		return tn.broadcastMsgsFrom(ctx, keyName, func(addr string) ([]sdk.Msg, error) {
+			return []sdk.Msg{&circuittypes.MsgResetCircuitBreaker{Authority: addr, MsgTypeUrls: typeURLs}}, nil
+		})
+	}
+
+	_, err := tn.ExecTx(ctx, keyName, append([]string{"circuit", "reset"}, typeURLs...)...)
+	return err
+}
+
+// CircuitAuthorizeMsg builds a message granting grantee the permission to trip and reset circuit breakers,
+// for use with BuildProposal. The granter is the x/gov module account if granter is empty.
+func (c *CosmosChain) CircuitAuthorizeMsg(ctx context.Context, granter, grantee string, level circuittypes.Permissions_Level, limitTypeURLs ...string) (*circuittypes.MsgAuthorizeCircuitBreaker, error) {
+	granter, err := c.authorityOrGov(ctx, granter)
+	if err != nil {
+		return nil, err
+	}
+	return &circuittypes.MsgAuthorizeCircuitBreaker{
+		Granter: granter,
+		Grantee: grantee,
+		Permissions: &circuittypes.Permissions{
+			Level:         level,
+			LimitTypeUrls: prefixMsgTypes(limitTypeURLs),
+		},
+	}, nil
+}
+
+// CircuitDisableMsg builds a message tripping the circuit breaker of the given message types,
+// for use with BuildProposal. The authority is the x/gov module account if authority is empty.
+func (c *CosmosChain) CircuitDisableMsg(ctx context.Context, authority string, typeURLs ...string) (*circuittypes.MsgTripCircuitBreaker, error) {
+	authority, err := c.authorityOrGov(ctx, authority)
+	if err != nil {
+		return nil, err
+	}
+	return &circuittypes.MsgTripCircuitBreaker{Authority: authority, MsgTypeUrls: prefixMsgTypes(typeURLs)}, nil
+}
+
+// CircuitResetMsg builds a message resetting the circuit breaker of the given message types,
+// for use with BuildProposal. The authority is the x/gov module account if authority is empty.
+func (c *CosmosChain) CircuitResetMsg(ctx context.Context, authority string, typeURLs ...string) (*circuittypes.MsgResetCircuitBreaker, error) {
+	authority, err := c.authorityOrGov(ctx, authority)
+	if err != nil {
+		return nil, err
+	}
+	return &circuittypes.MsgResetCircuitBreaker{Authority: authority, MsgTypeUrls: prefixMsgTypes(typeURLs)}, nil
+}
+
+// CircuitQueryAccount returns the circuit breaker permissions of an account.
+func (c *CosmosChain) CircuitQueryAccount(ctx context.Context, address string) (*circuittypes.Permissions, error) {
+	res, err := circuittypes.NewQueryClient(c.GetNode().GrpcConn).Account(ctx, &circuittypes.QueryAccountRequest{Address: address})
+	if err != nil {
+		return nil, err
+	}
+	return res.Permission, nil
+}
+
+// CircuitQueryAccounts returns the circuit breaker permissions of all accounts.
+func (c *CosmosChain) CircuitQueryAccounts(ctx context.Context) ([]*circuittypes.GenesisAccountPermissions, error) {
+	res, err := circuittypes.NewQueryClient(c.GetNode().GrpcConn).Accounts(ctx, &circuittypes.QueryAccountsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return res.Accounts, nil
+}
+
+// CircuitQueryDisabledList returns the type URLs of the messages whose circuit breaker is tripped.
+func (c *CosmosChain) CircuitQueryDisabledList(ctx context.Context) ([]string, error) {
+	res, err := circuittypes.NewQueryClient(c.GetNode().GrpcConn).DisabledList(ctx, &circuittypes.QueryDisabledListRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return res.DisabledList, nil
+}
+
+// authorityOrGov returns authority, or the address of the x/gov module account if authority is empty.
+func (c *CosmosChain) authorityOrGov(ctx context.Context, authority string) (string, error) {
+	if authority != "" {
+		return authority, nil
+	}
+	return c.AuthQueryModuleAddress(ctx, "gov")
+}
+
+// prefixMsgTypes applies PrefixMsgTypeIfRequired to each of msgTypes.
+func prefixMsgTypes(msgTypes []string) []string {
+	prefixed := make([]string, len(msgTypes))
+	for i, msgType := range msgTypes {
+		prefixed[i] = PrefixMsgTypeIfRequired(msgType)
+	}
+	return prefixed
+}
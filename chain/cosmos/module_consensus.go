@@ -0,0 +1,46 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+
+	consensustypes "github.com/cosmos/cosmos-sdk/x/consensus/types"
+)
+
+// ConsensusQueryParams returns the consensus params of the chain.
+func (c *CosmosChain) ConsensusQueryParams(ctx context.Context) (*cmtproto.ConsensusParams, error) {
+	res, err := consensustypes.NewQueryClient(c.GetNode().GrpcConn).Params(ctx, &consensustypes.QueryParamsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return res.Params, nil
+}
+
+// ConsensusUpdateParamsMsg builds a message updating the consensus params of the chain, for use with BuildProposal.
+// x/consensus requires every param to be set, so update is applied to the current params of the chain.
+// The authority is the x/gov module account if authority is empty.
+func (c *CosmosChain) ConsensusUpdateParamsMsg(ctx context.Context, authority string, update func(*cmtproto.ConsensusParams)) (*consensustypes.MsgUpdateParams, error) {
+	authority, err := c.authorityOrGov(ctx, authority)
+	if err != nil {
+		return nil, err
+	}
+
+	params, err := c.ConsensusQueryParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if params == nil {
+		return nil, fmt.Errorf("no consensus params on chain %s", c.Config().ChainID)
+	}
+	update(params)
+
+	return &consensustypes.MsgUpdateParams{
+		Authority: authority,
+		Block:     params.Block,
+		Evidence:  params.Evidence,
+		Validator: params.Validator,
+		Abci:      params.Abci,
+	}, nil
+}
@@ -9,9 +9,15 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/CosmWasm/wasmd/x/wasm/ioutils"
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
 
 	"github.com/cosmos/cosmos-sdk/types"
 
+	"github.com/strangelove-ventures/interchaintest/v8/chain/internal/tendermint"
 	"github.com/strangelove-ventures/interchaintest/v8/testutil"
 )
 
@@ -43,7 +49,12 @@ type CodeInfosResponse struct {
 }
 
 // StoreContract takes a file path to smart contract and stores it on-chain. Returns the contracts code id.
+// With ibc.TxModeGRPC, the instantiate permission flags and the transaction flags are supported in extraExecTxArgs.
 func (tn *ChainNode) StoreContract(ctx context.Context, keyName string, fileName string, extraExecTxArgs ...string) (string, error) {
+	if tn.UsesGRPCTx() {
+		return tn.broadcastStoreCode(ctx, keyName, fileName, extraExecTxArgs)
+	}
+
 	_, file := filepath.Split(fileName)
 	err := tn.CopyFile(ctx, fileName, file)
 	if err != nil {
@@ -76,7 +87,12 @@ func (tn *ChainNode) StoreContract(ctx context.Context, keyName string, fileName
 }
 
 // InstantiateContract takes a code id for a smart contract and initialization message and returns the instantiated contract address.
+// With ibc.TxModeGRPC, the --label, --admin and --amount flags and the transaction flags are supported in extraExecTxArgs.
 func (tn *ChainNode) InstantiateContract(ctx context.Context, keyName string, codeID string, initMessage string, needsNoAdminFlag bool, extraExecTxArgs ...string) (string, error) {
+	if tn.UsesGRPCTx() {
+		return tn.broadcastInstantiateContract(ctx, keyName, codeID, initMessage, needsNoAdminFlag, extraExecTxArgs)
+	}
+
 	command := []string{"wasm", "instantiate", codeID, initMessage, "--label", "wasm-contract"}
 	command = append(command, extraExecTxArgs...)
 	if needsNoAdminFlag {
@@ -110,7 +126,18 @@ func (tn *ChainNode) InstantiateContract(ctx context.Context, keyName string, co
 }
 
 // ExecuteContract executes a contract transaction with a message using it's address.
+// With ibc.TxModeGRPC, the --amount flag and the transaction flags are supported in extraExecTxArgs.
 func (tn *ChainNode) ExecuteContract(ctx context.Context, keyName string, contractAddress string, message string, extraExecTxArgs ...string) (res *types.TxResponse, err error) {
+	if tn.UsesGRPCTx() {
+		return tn.broadcastWasmMsg(ctx, keyName, extraExecTxArgs, []string{"amount"}, func(sender string, txf txFlags) (types.Msg, error) {
+			funds, err := types.ParseCoinsNormalized(txf["amount"])
+			if err != nil {
+				return nil, fmt.Errorf("invalid amount %q: %w", txf["amount"], err)
+			}
+			return &wasmtypes.MsgExecuteContract{Sender: sender, Contract: contractAddress, Msg: wasmtypes.RawContractMessage(message), Funds: funds}, nil
+		})
+	}
+
 	cmd := []string{"wasm", "execute", contractAddress, message}
 	cmd = append(cmd, extraExecTxArgs...)
 
@@ -162,7 +189,18 @@ func (tn *ChainNode) QueryContract(ctx context.Context, contractAddress string,
 }
 
 // MigrateContract performs contract migration.
+// With ibc.TxModeGRPC, the transaction flags are supported in extraExecTxArgs.
 func (tn *ChainNode) MigrateContract(ctx context.Context, keyName string, contractAddress string, codeID string, message string, extraExecTxArgs ...string) (res *types.TxResponse, err error) {
+	if tn.UsesGRPCTx() {
+		id, err := strconv.ParseUint(codeID, 10, 64)
+		if err != nil {
+			return &types.TxResponse{}, fmt.Errorf("invalid code id %q: %w", codeID, err)
+		}
+		return tn.broadcastWasmMsg(ctx, keyName, extraExecTxArgs, nil, func(sender string, _ txFlags) (types.Msg, error) {
+			return &wasmtypes.MsgMigrateContract{Sender: sender, Contract: contractAddress, CodeID: id, Msg: wasmtypes.RawContractMessage(message)}, nil
+		})
+	}
+
 	cmd := []string{"wasm", "migrate", contractAddress, codeID, message}
 	cmd = append(cmd, extraExecTxArgs...)
 
@@ -183,6 +221,113 @@ func (tn *ChainNode) MigrateContract(ctx context.Context, keyName string, contra
 	return txResp, nil
 }
 
+// broadcastStoreCode stores the contract of the local file fileName for StoreContract with ibc.TxModeGRPC.
+func (tn *ChainNode) broadcastStoreCode(ctx context.Context, keyName, fileName string, extraFlags []string) (string, error) {
+	txf, err := parseTxFlags(extraFlags, "instantiate-everybody", "instantiate-nobody", "instantiate-anyof-addresses")
+	if err != nil {
+		return "", err
+	}
+	if _, ok := txf["gas"]; !ok {
+		txf["gas"] = "auto"
+	}
+
+	code, err := os.ReadFile(fileName)
+	if err != nil {
+		return "", err
+	}
+	if ioutils.IsWasm(code) {
+		if code, err = ioutils.GzipIt(code); err != nil {
+			return "", err
+		}
+	}
+
+	var permission *wasmtypes.AccessConfig
+	switch {
+	case txf["instantiate-anyof-addresses"] != "":
+		permission = &wasmtypes.AccessConfig{
+			Permission: wasmtypes.AccessTypeAnyOfAddresses,
+			Addresses:  strings.Split(txf["instantiate-anyof-addresses"], ","),
+		}
+	case txf["instantiate-nobody"] == "true":
+		permission = &wasmtypes.AllowNobody
+	case txf["instantiate-everybody"] == "true":
+		permission = &wasmtypes.AllowEverybody
+	}
+
+	sender, err := tn.KeyAddress(ctx, keyName)
+	if err != nil {
+		return "", err
+	}
+	res, err := tn.broadcastTx(ctx, keyName, txf, &wasmtypes.MsgStoreCode{Sender: sender, WASMByteCode: code, InstantiatePermission: permission})
+	if err != nil {
+		return "", err
+	}
+
+	codeID, ok := tendermint.AttributeValue(res.Events, wasmtypes.EventTypeStoreCode, wasmtypes.AttributeKeyCodeID)
+	if !ok {
+		return "", fmt.Errorf("code id not found in events of transaction %s", res.TxHash)
+	}
+	return codeID, nil
+}
+
+// broadcastInstantiateContract instantiates the contract for InstantiateContract with ibc.TxModeGRPC.
+func (tn *ChainNode) broadcastInstantiateContract(ctx context.Context, keyName, codeID, initMessage string, noAdmin bool, extraFlags []string) (string, error) {
+	id, err := strconv.ParseUint(codeID, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid code id %q: %w", codeID, err)
+	}
+
+	res, err := tn.broadcastWasmMsg(ctx, keyName, extraFlags, []string{"label", "admin", "amount"}, func(sender string, txf txFlags) (types.Msg, error) {
+		funds, err := types.ParseCoinsNormalized(txf["amount"])
+		if err != nil {
+			return nil, fmt.Errorf("invalid amount %q: %w", txf["amount"], err)
+		}
+		label := "wasm-contract"
+		if v, ok := txf["label"]; ok {
+			label = v
+		}
+		admin := txf["admin"]
+		if noAdmin {
+			admin = ""
+		}
+		return &wasmtypes.MsgInstantiateContract{
+			Sender: sender,
+			Admin:  admin,
+			CodeID: id,
+			Label:  label,
+			Msg:    wasmtypes.RawContractMessage(initMessage),
+			Funds:  funds,
+		}, nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	contractAddress, ok := tendermint.AttributeValue(res.Events, wasmtypes.EventTypeInstantiate, wasmtypes.AttributeKeyContractAddr)
+	if !ok {
+		return "", fmt.Errorf("contract address not found in events of transaction %s", res.TxHash)
+	}
+	return contractAddress, nil
+}
+
+// broadcastWasmMsg broadcasts the wasm msg built from the address of keyName and the msgFlags parsed from extraFlags.
+func (tn *ChainNode) broadcastWasmMsg(ctx context.Context, keyName string, extraFlags, msgFlags []string, build func(sender string, txf txFlags) (types.Msg, error)) (*types.TxResponse, error) {
+	txf, err := parseTxFlags(extraFlags, msgFlags...)
+	if err != nil {
+		return &types.TxResponse{}, err
+	}
+	sender, err := tn.KeyAddress(ctx, keyName)
+	if err != nil {
+		return &types.TxResponse{}, err
+	}
+	msg, err := build(sender, txf)
+	if err != nil {
+		return &types.TxResponse{}, err
+	}
+	res, err := tn.broadcastTx(ctx, keyName, txf, msg)
+	return &res, err
+}
+
 // StoreClientContract takes a file path to a client smart contract and stores it on-chain. Returns the contracts code id.
 func (tn *ChainNode) StoreClientContract(ctx context.Context, keyName string, fileName string, extraExecTxArgs ...string) (string, error) {
 	content, err := os.ReadFile(fileName)
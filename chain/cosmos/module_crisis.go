@@ -2,10 +2,19 @@ package cosmos
 
 import (
 	"context"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	crisistypes "github.com/cosmos/cosmos-sdk/x/crisis/types"
 )
 
 // CrisisInvariantBroken executes the crisis invariant broken command.
 func (tn *ChainNode) CrisisInvariantBroken(ctx context.Context, keyName, moduleName, route string) error {
+	if tn.UsesGRPCTx() {
+		return tn.broadcastMsgsFrom(ctx, keyName, func(sender string) ([]sdk.Msg, error) {
+			return []sdk.Msg{&crisistypes.MsgVerifyInvariant{Sender: sender, InvariantModuleName: moduleName, InvariantRoute: route}}, nil
+		})
+	}
+
 	_, err := tn.ExecTx(ctx,
 		keyName, "crisis", "invariant-broken", moduleName, route,
 	)
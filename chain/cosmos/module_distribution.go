@@ -9,6 +9,16 @@ import (
 
 // DistributionFundCommunityPool funds the community pool with the specified amount of coins.
 func (tn *ChainNode) DistributionFundCommunityPool(ctx context.Context, keyName, amount string) error {
+	if tn.UsesGRPCTx() {
+		coins, err := sdk.ParseCoinsNormalized(amount)
+		if err != nil {
+			return err
+		}
+		return tn.broadcastMsgsFrom(ctx, keyName, func(addr string) ([]sdk.Msg, error) {
+			return []sdk.Msg{&distrtypes.MsgFundCommunityPool{Depositor: addr, Amount: coins}}, nil
+		})
+	}
+
 	_, err := tn.ExecTx(ctx,
 		keyName, "distribution", "fund-community-pool", amount,
 	)
@@ -16,6 +26,16 @@ func (tn *ChainNode) DistributionFundCommunityPool(ctx context.Context, keyName,
 }
 
 func (tn *ChainNode) DistributionFundValidatorRewardsPool(ctx context.Context, keyName, valAddr, amount string) error {
+	if tn.UsesGRPCTx() {
+		coins, err := sdk.ParseCoinsNormalized(amount)
+		if err != nil {
+			return err
+		}
+		return tn.broadcastMsgsFrom(ctx, keyName, func(addr string) ([]sdk.Msg, error) {
+			return []sdk.Msg{&distrtypes.MsgDepositValidatorRewardsPool{Depositor: addr, ValidatorAddress: valAddr, Amount: coins}}, nil
+		})
+	}
+
 	_, err := tn.ExecTx(ctx,
 		keyName, "distribution", "fund-validator-rewards-pool", valAddr, amount,
 	)
@@ -24,6 +44,12 @@ func (tn *ChainNode) DistributionFundValidatorRewardsPool(ctx context.Context, k
 
 // DistributionSetWithdrawAddr change the default withdraw address for rewards associated with an address.
 func (tn *ChainNode) DistributionSetWithdrawAddr(ctx context.Context, keyName, withdrawAddr string) error {
+	if tn.UsesGRPCTx() {
+		return tn.broadcastMsgsFrom(ctx, keyName, func(addr string) ([]sdk.Msg, error) {
+			return []sdk.Msg{&distrtypes.MsgSetWithdrawAddress{DelegatorAddress: addr, WithdrawAddress: withdrawAddr}}, nil
+		})
+	}
+
 	_, err := tn.ExecTx(ctx,
 		keyName, "distribution", "set-withdraw-addr", withdrawAddr,
 	)
@@ -32,6 +58,21 @@ func (tn *ChainNode) DistributionSetWithdrawAddr(ctx context.Context, keyName, w
 
 // DistributionWithdrawAllRewards withdraws all delegations rewards for a delegator.
 func (tn *ChainNode) DistributionWithdrawAllRewards(ctx context.Context, keyName string) error {
+	if tn.UsesGRPCTx() {
+		return tn.broadcastMsgsFrom(ctx, keyName, func(addr string) ([]sdk.Msg, error) {
+			res, err := distrtypes.NewQueryClient(tn.GrpcConn).
+				DelegatorValidators(ctx, &distrtypes.QueryDelegatorValidatorsRequest{DelegatorAddress: addr})
+			if err != nil {
+				return nil, err
+			}
+			msgs := make([]sdk.Msg, len(res.Validators))
+			for i, valAddr := range res.Validators {
+				msgs[i] = &distrtypes.MsgWithdrawDelegatorReward{DelegatorAddress: addr, ValidatorAddress: valAddr}
+			}
+			return msgs, nil
+		})
+	}
+
 	_, err := tn.ExecTx(ctx,
 		keyName, "distribution", "withdraw-all-rewards",
 	)
@@ -41,6 +82,16 @@ func (tn *ChainNode) DistributionWithdrawAllRewards(ctx context.Context, keyName
 // DistributionWithdrawValidatorRewards withdraws all delegations rewards for a delegator.
 // If includeCommission is true, it also withdraws the validator's commission.
 func (tn *ChainNode) DistributionWithdrawValidatorRewards(ctx context.Context, keyName, valAddr string, includeCommission bool) error {
+	if tn.UsesGRPCTx() {
+		return tn.broadcastMsgsFrom(ctx, keyName, func(addr string) ([]sdk.Msg, error) {
+			msgs := []sdk.Msg{&distrtypes.MsgWithdrawDelegatorReward{DelegatorAddress: addr, ValidatorAddress: valAddr}}
+			if includeCommission {
+				msgs = append(msgs, &distrtypes.MsgWithdrawValidatorCommission{ValidatorAddress: valAddr})
+			}
+			return msgs, nil
+		})
+	}
+
 	cmd := []string{"distribution", "withdraw-rewards", valAddr}
 
 	if includeCommission {
@@ -2,14 +2,26 @@ package cosmos
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
 	"cosmossdk.io/x/feegrant"
+
+	"github.com/cosmos/gogoproto/proto"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 // FeeGrant grants a fee grant.
+// With ibc.TxModeGRPC, the flags --period and --period-limit of a periodic allowance are supported in extraFlags.
 func (tn *ChainNode) FeeGrant(ctx context.Context, granterKey, grantee, spendLimit string, allowedMsgs []string, expiration time.Time, extraFlags ...string) error {
+	if tn.UsesGRPCTx() {
+		return tn.broadcastFeeGrant(ctx, granterKey, grantee, spendLimit, allowedMsgs, expiration, extraFlags)
+	}
+
 	cmd := []string{"feegrant", "grant", granterKey, grantee, "--spend-limit", spendLimit}
 
 	if len(allowedMsgs) > 0 {
@@ -32,8 +44,69 @@ func (tn *ChainNode) FeeGrant(ctx context.Context, granterKey, grantee, spendLim
 	return err
 }
 
+// broadcastFeeGrant is the gRPC counterpart of the feegrant grant command run by FeeGrant.
+func (tn *ChainNode) broadcastFeeGrant(ctx context.Context, granterKey, grantee, spendLimit string, allowedMsgs []string, expiration time.Time, extraFlags []string) error {
+	flags, err := parseTxFlags(extraFlags, "period", "period-limit")
+	if err != nil {
+		return err
+	}
+
+	limit, err := sdk.ParseCoinsNormalized(spendLimit)
+	if err != nil {
+		return err
+	}
+	basic := feegrant.BasicAllowance{SpendLimit: limit}
+	if expiration.After(time.Now()) {
+		basic.Expiration = &expiration
+	}
+
+	var allowance feegrant.FeeAllowanceI = &basic
+	if period, ok := flags["period"]; ok {
+		seconds, err := strconv.ParseInt(period, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid period %q: %w", period, err)
+		}
+		periodLimit, err := sdk.ParseCoinsNormalized(flags["period-limit"])
+		if err != nil {
+			return err
+		}
+		allowance = &feegrant.PeriodicAllowance{
+			Basic:            basic,
+			Period:           time.Duration(seconds) * time.Second,
+			PeriodSpendLimit: periodLimit,
+			PeriodCanSpend:   periodLimit,
+			PeriodReset:      time.Now().Add(time.Duration(seconds) * time.Second),
+		}
+	}
+	if len(allowedMsgs) > 0 {
+		msgs := make([]string, len(allowedMsgs))
+		for i, msg := range allowedMsgs {
+			msgs[i] = PrefixMsgTypeIfRequired(msg)
+		}
+		if allowance, err = feegrant.NewAllowedMsgAllowance(allowance, msgs); err != nil {
+			return err
+		}
+	}
+
+	anyAllowance, err := codectypes.NewAnyWithValue(allowance.(proto.Message))
+	if err != nil {
+		return err
+	}
+	granter, err := tn.KeyAddress(ctx, granterKey)
+	if err != nil {
+		return err
+	}
+	_, err = tn.broadcastTx(ctx, granterKey, flags, &feegrant.MsgGrantAllowance{Granter: granter, Grantee: grantee, Allowance: anyAllowance})
+	return err
+}
+
 // FeeGrantRevoke revokes a fee grant.
 func (tn *ChainNode) FeeGrantRevoke(ctx context.Context, keyName, granterAddr, granteeAddr string) error {
+	if tn.UsesGRPCTx() {
+		_, err := tn.BroadcastMsgs(ctx, keyName, &feegrant.MsgRevokeAllowance{Granter: granterAddr, Grantee: granteeAddr})
+		return err
+	}
+
 	_, err := tn.ExecTx(ctx, keyName, "feegrant", "revoke", granterAddr, granteeAddr)
 	return err
 }
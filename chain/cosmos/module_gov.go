@@ -11,15 +11,28 @@ import (
 
 	upgradetypes "cosmossdk.io/x/upgrade/types"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	govutils "github.com/cosmos/cosmos-sdk/x/gov/client/utils"
 	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
 	govv1beta1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1beta1"
 	paramsutils "github.com/cosmos/cosmos-sdk/x/params/client/utils"
+	paramsproposal "github.com/cosmos/cosmos-sdk/x/params/types/proposal"
 
 	"github.com/strangelove-ventures/interchaintest/v8/dockerutil"
 )
 
 // VoteOnProposal submits a vote for the specified proposal.
 func (tn *ChainNode) VoteOnProposal(ctx context.Context, keyName string, proposalID uint64, vote string) error {
+	if tn.UsesGRPCTx() {
+		option, err := govv1.VoteOptionFromString(govutils.NormalizeVoteOption(vote))
+		if err != nil {
+			return err
+		}
+		return tn.broadcastMsgsFrom(ctx, keyName, func(voter string) ([]sdk.Msg, error) {
+			return []sdk.Msg{&govv1.MsgVote{ProposalId: proposalID, Voter: voter, Option: option}}, nil
+		})
+	}
+
 	_, err := tn.ExecTx(ctx, keyName,
 		"gov", "vote",
 		fmt.Sprintf("%d", proposalID), vote, "--gas", "auto",
@@ -29,6 +42,10 @@ func (tn *ChainNode) VoteOnProposal(ctx context.Context, keyName string, proposa
 
 // SubmitProposal submits a gov v1 proposal to the chain.
 func (tn *ChainNode) SubmitProposal(ctx context.Context, keyName string, prop TxProposalv1) (string, error) {
+	if tn.UsesGRPCTx() {
+		return tn.broadcastProposal(ctx, keyName, prop)
+	}
+
 	file := "proposal.json"
 	propJSON, err := json.MarshalIndent(prop, "", " ")
 	if err != nil {
@@ -48,6 +65,34 @@ func (tn *ChainNode) SubmitProposal(ctx context.Context, keyName string, prop Tx
 	return tn.ExecTx(ctx, keyName, command...)
 }
 
+// broadcastProposal is the gRPC counterpart of the gov submit-proposal command run by SubmitProposal.
+func (tn *ChainNode) broadcastProposal(ctx context.Context, keyName string, prop TxProposalv1) (string, error) {
+	cdc := tn.Chain.Config().EncodingConfig.Codec
+	msgs := make([]sdk.Msg, len(prop.Messages))
+	for i, raw := range prop.Messages {
+		if err := cdc.UnmarshalInterfaceJSON(raw, &msgs[i]); err != nil {
+			return "", fmt.Errorf("failed to decode proposal message %d: %w", i, err)
+		}
+	}
+
+	deposit, err := sdk.ParseCoinsNormalized(prop.Deposit)
+	if err != nil {
+		return "", err
+	}
+
+	// As with the command, the proposer is always the signer.
+	proposer, err := tn.KeyAddress(ctx, keyName)
+	if err != nil {
+		return "", err
+	}
+
+	msg, err := govv1.NewMsgSubmitProposal(msgs, deposit, proposer, prop.Metadata, prop.Title, prop.Summary, prop.Expedited)
+	if err != nil {
+		return "", err
+	}
+	return tn.BroadcastMsgs(ctx, keyName, msg)
+}
+
 // GovSubmitProposal is an alias for SubmitProposal.
 func (tn *ChainNode) GovSubmitProposal(ctx context.Context, keyName string, prop TxProposalv1) (string, error) {
 	return tn.SubmitProposal(ctx, keyName, prop)
@@ -55,7 +100,7 @@ func (tn *ChainNode) GovSubmitProposal(ctx context.Context, keyName string, prop
 
 // UpgradeProposal submits a software-upgrade governance proposal to the chain.
 func (tn *ChainNode) UpgradeProposal(ctx context.Context, keyName string, prop SoftwareUpgradeProposal) (string, error) {
-	if tn.IsAboveSDK47(ctx) {
+	if tn.UsesGRPCTx() || tn.IsAboveSDK47(ctx) {
 		cosmosChain := tn.Chain.(*CosmosChain)
 
 		if prop.Authority == "" {
@@ -99,6 +144,16 @@ func (tn *ChainNode) UpgradeProposal(ctx context.Context, keyName string, prop S
 
 // TextProposal submits a text governance proposal to the chain.
 func (tn *ChainNode) TextProposal(ctx context.Context, keyName string, prop TextProposal) (string, error) {
+	if tn.UsesGRPCTx() {
+		// A gov v1 proposal without messages is a text proposal.
+		return tn.broadcastProposal(ctx, keyName, TxProposalv1{
+			Deposit:   prop.Deposit,
+			Title:     prop.Title,
+			Summary:   prop.Description,
+			Expedited: prop.Expedited,
+		})
+	}
+
 	command := []string{
 		"gov", "submit-proposal",
 		"--type", "text",
@@ -114,6 +169,11 @@ func (tn *ChainNode) TextProposal(ctx context.Context, keyName string, prop Text
 
 // ParamChangeProposal submits a param change proposal to the chain, signed by keyName.
 func (tn *ChainNode) ParamChangeProposal(ctx context.Context, keyName string, prop *paramsutils.ParamChangeProposalJSON) (string, error) {
+	if tn.UsesGRPCTx() {
+		content := paramsproposal.NewParameterChangeProposal(prop.Title, prop.Description, prop.Changes.ToParamChanges())
+		return tn.broadcastLegacyProposal(ctx, keyName, content, prop.Deposit)
+	}
+
 	content, err := json.Marshal(prop)
 	if err != nil {
 		return "", err
@@ -137,6 +197,27 @@ func (tn *ChainNode) ParamChangeProposal(ctx context.Context, keyName string, pr
 	return tn.ExecTx(ctx, keyName, command...)
 }
 
+// broadcastLegacyProposal is the gRPC counterpart of the gov submit-legacy-proposal command,
+// submitting the content of a gov v1beta1 proposal with the deposit.
+func (tn *ChainNode) broadcastLegacyProposal(ctx context.Context, keyName string, content govv1beta1.Content, deposit string) (string, error) {
+	initialDeposit, err := sdk.ParseCoinsNormalized(deposit)
+	if err != nil {
+		return "", err
+	}
+
+	proposer, err := tn.KeyAddress(ctx, keyName)
+	if err != nil {
+		return "", err
+	}
+
+	// NewMsgSubmitProposal formats the proposer with the global bech32 prefix, so build the msg directly.
+	msg := &govv1beta1.MsgSubmitProposal{InitialDeposit: initialDeposit, Proposer: proposer}
+	if err := msg.SetContent(content); err != nil {
+		return "", err
+	}
+	return tn.BroadcastMsgs(ctx, keyName, msg)
+}
+
 // Build a gov v1 proposal type.
 //
 // The proposer field should only be set for IBC-Go v8 / SDK v50 chains.
@@ -0,0 +1,467 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/group"
+
+	"github.com/strangelove-ventures/interchaintest/v8/chain/internal/tendermint"
+	"github.com/strangelove-ventures/interchaintest/v8/dockerutil"
+)
+
+// GroupCreate creates a group administered by keyName, and returns its ID.
+func (tn *ChainNode) GroupCreate(ctx context.Context, keyName, metadata string, members []group.MemberRequest) (uint64, error) {
+	var txHash string
+	if tn.UsesGRPCTx() {
+		admin, err := tn.KeyAddress(ctx, keyName)
+		if err != nil {
+			return 0, err
+		}
+		txHash, err = tn.BroadcastMsgs(ctx, keyName, &group.MsgCreateGroup{Admin: admin, Members: members, Metadata: metadata})
+		if err != nil {
+			return 0, err
+		}
+	} else {
+		membersFile, err := tn.writeGroupMembersFile(ctx, members)
+		if err != nil {
+			return 0, err
+		}
+		txHash, err = tn.ExecTx(ctx, keyName, "group", "create-group", keyName, metadata, membersFile)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return tn.groupEventID(txHash, "cosmos.group.v1.EventCreateGroup", "group_id")
+}
+
+// GroupCreatePolicy creates a group policy administered by keyName for the group, and returns its address.
+func (tn *ChainNode) GroupCreatePolicy(ctx context.Context, keyName string, groupID uint64, metadata string, policy group.DecisionPolicy) (string, error) {
+	var txHash string
+	if tn.UsesGRPCTx() {
+		admin, err := tn.KeyAddress(ctx, keyName)
+		if err != nil {
+			return "", err
+		}
+		msg := &group.MsgCreateGroupPolicy{Admin: admin, GroupId: groupID, Metadata: metadata}
+		if err := msg.SetDecisionPolicy(policy); err != nil {
+			return "", err
+		}
+		txHash, err = tn.BroadcastMsgs(ctx, keyName, msg)
+		if err != nil {
+			return "", err
+		}
+	} else {
+		policyFile, err := tn.writeGroupPolicyFile(ctx, policy)
+		if err != nil {
+			return "", err
+		}
+		txHash, err = tn.ExecTx(ctx, keyName,
+			"group", "create-group-policy", keyName, strconv.FormatUint(groupID, 10), metadata, policyFile,
+		)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return tn.groupEventValue(txHash, "cosmos.group.v1.EventCreateGroupPolicy", "address")
+}
+
+// GroupCreateWithPolicy creates a group and a group policy for it, both administered by keyName,
+// and returns the ID of the group and the address of the policy.
+// If policyAsAdmin is true, the policy becomes the admin of the group and of itself.
+func (tn *ChainNode) GroupCreateWithPolicy(
+	ctx context.Context,
+	keyName, groupMetadata, policyMetadata string,
+	members []group.MemberRequest,
+	policy group.DecisionPolicy,
+	policyAsAdmin bool,
+) (uint64, string, error) {
+	var txHash string
+	if tn.UsesGRPCTx() {
+		admin, err := tn.KeyAddress(ctx, keyName)
+		if err != nil {
+			return 0, "", err
+		}
+		msg := &group.MsgCreateGroupWithPolicy{
+			Admin:               admin,
+			Members:             members,
+			GroupMetadata:       groupMetadata,
+			GroupPolicyMetadata: policyMetadata,
+			GroupPolicyAsAdmin:  policyAsAdmin,
+		}
+		if err := msg.SetDecisionPolicy(policy); err != nil {
+			return 0, "", err
+		}
+		txHash, err = tn.BroadcastMsgs(ctx, keyName, msg)
+		if err != nil {
+			return 0, "", err
+		}
+	} else {
+		membersFile, err := tn.writeGroupMembersFile(ctx, members)
+		if err != nil {
+			return 0, "", err
+		}
+		policyFile, err := tn.writeGroupPolicyFile(ctx, policy)
+		if err != nil {
+			return 0, "", err
+		}
+		txHash, err = tn.ExecTx(ctx, keyName,
+			"group", "create-group-with-policy", keyName, groupMetadata, policyMetadata, membersFile, policyFile,
+			fmt.Sprintf("--group-policy-as-admin=%t", policyAsAdmin),
+		)
+		if err != nil {
+			return 0, "", err
+		}
+	}
+
+	groupID, err := tn.groupEventID(txHash, "cosmos.group.v1.EventCreateGroup", "group_id")
+	if err != nil {
+		return 0, "", err
+	}
+	policyAddr, err := tn.groupEventValue(txHash, "cosmos.group.v1.EventCreateGroupPolicy", "address")
+	if err != nil {
+		return 0, "", err
+	}
+	return groupID, policyAddr, nil
+}
+
+// GroupSubmitProposal submits a group proposal signed by keyName, and returns its ID.
+// keyName is added to the proposers if prop has none.
+// If exec is true, the proposal is executed right away if the votes of its proposers are enough to accept it.
+func (tn *ChainNode) GroupSubmitProposal(ctx context.Context, keyName string, prop GroupProposal, exec bool) (uint64, error) {
+	if len(prop.Proposers) == 0 {
+		proposer, err := tn.KeyAddress(ctx, keyName)
+		if err != nil {
+			return 0, err
+		}
+		prop.Proposers = []string{proposer}
+	}
+
+	var txHash string
+	if tn.UsesGRPCTx() {
+		cdc := tn.Chain.Config().EncodingConfig.Codec
+		msgs := make([]sdk.Msg, len(prop.Messages))
+		for i, raw := range prop.Messages {
+			if err := cdc.UnmarshalInterfaceJSON(raw, &msgs[i]); err != nil {
+				return 0, fmt.Errorf("failed to decode proposal message %d: %w", i, err)
+			}
+		}
+		msg, err := group.NewMsgSubmitProposal(
+			prop.GroupPolicyAddress, prop.Proposers, msgs, prop.Metadata, groupExec(exec), prop.Title, prop.Summary,
+		)
+		if err != nil {
+			return 0, err
+		}
+		txHash, err = tn.BroadcastMsgs(ctx, keyName, msg)
+		if err != nil {
+			return 0, err
+		}
+	} else {
+		propJSON, err := json.MarshalIndent(prop, "", " ")
+		if err != nil {
+			return 0, err
+		}
+		file := "group_proposal_" + dockerutil.RandLowerCaseLetterString(4) + ".json"
+		if err := tn.WriteFile(ctx, propJSON, file); err != nil {
+			return 0, fmt.Errorf("writing group proposal: %w", err)
+		}
+
+		command := []string{"group", "submit-proposal", path.Join(tn.HomeDir(), file)}
+		if exec {
+			command = append(command, "--exec", "try")
+		}
+		// The command signs with the first proposer, so keyName must be it.
+		txHash, err = tn.ExecTx(ctx, keyName, command...)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return tn.groupEventID(txHash, "cosmos.group.v1.EventSubmitProposal", "proposal_id")
+}
+
+// GroupVote votes on a group proposal as keyName.
+// If exec is true, the proposal is executed right away if the vote makes it accepted.
+func (tn *ChainNode) GroupVote(ctx context.Context, keyName string, proposalID uint64, option group.VoteOption, metadata string, exec bool) error {
+	voter, err := tn.KeyAddress(ctx, keyName)
+	if err != nil {
+		return err
+	}
+
+	if tn.UsesGRPCTx() {
+		_, err := tn.BroadcastMsgs(ctx, keyName, &group.MsgVote{
+			ProposalId: proposalID,
+			Voter:      voter,
+			Option:     option,
+			Metadata:   metadata,
+			Exec:       groupExec(exec),
+		})
+		return err
+	}
+
+	command := []string{"group", "vote", strconv.FormatUint(proposalID, 10), voter, option.String(), metadata}
+	if exec {
+		command = append(command, "--exec", "try")
+	}
+	_, err = tn.ExecTx(ctx, keyName, command...)
+	return err
+}
+
+// GroupExec executes an accepted group proposal as keyName.
+// The result of the execution is recorded in the proposal, see GroupQueryProposal.
+func (tn *ChainNode) GroupExec(ctx context.Context, keyName string, proposalID uint64) error {
+	if tn.UsesGRPCTx() {
+		return tn.broadcastMsgsFrom(ctx, keyName, func(executor string) ([]sdk.Msg, error) {
+			return []sdk.Msg{&group.MsgExec{ProposalId: proposalID, Executor: executor}}, nil
+		})
+	}
+
+	_, err := tn.ExecTx(ctx, keyName, "group", "exec", strconv.FormatUint(proposalID, 10))
+	return err
+}
+
+// GroupWithdrawProposal withdraws a group proposal as keyName, which must be a proposer or the admin of its policy.
+func (tn *ChainNode) GroupWithdrawProposal(ctx context.Context, keyName string, proposalID uint64) error {
+	addr, err := tn.KeyAddress(ctx, keyName)
+	if err != nil {
+		return err
+	}
+
+	if tn.UsesGRPCTx() {
+		_, err := tn.BroadcastMsgs(ctx, keyName, &group.MsgWithdrawProposal{ProposalId: proposalID, Address: addr})
+		return err
+	}
+
+	_, err = tn.ExecTx(ctx, keyName, "group", "withdraw-proposal", strconv.FormatUint(proposalID, 10), addr)
+	return err
+}
+
+// GroupLeave removes keyName from the members of the group.
+func (tn *ChainNode) GroupLeave(ctx context.Context, keyName string, groupID uint64) error {
+	addr, err := tn.KeyAddress(ctx, keyName)
+	if err != nil {
+		return err
+	}
+
+	if tn.UsesGRPCTx() {
+		_, err := tn.BroadcastMsgs(ctx, keyName, &group.MsgLeaveGroup{Address: addr, GroupId: groupID})
+		return err
+	}
+
+	_, err = tn.ExecTx(ctx, keyName, "group", "leave-group", addr, strconv.FormatUint(groupID, 10))
+	return err
+}
+
+// writeGroupMembersFile writes the members file of the group commands to the node, and returns its path.
+func (tn *ChainNode) writeGroupMembersFile(ctx context.Context, members []group.MemberRequest) (string, error) {
+	membersJSON, err := json.Marshal(struct {
+		Members []group.MemberRequest `json:"members"`
+	}{members})
+	if err != nil {
+		return "", err
+	}
+
+	file := "group_members_" + dockerutil.RandLowerCaseLetterString(4) + ".json"
+	if err := tn.WriteFile(ctx, membersJSON, file); err != nil {
+		return "", fmt.Errorf("writing group members: %w", err)
+	}
+	return path.Join(tn.HomeDir(), file), nil
+}
+
+// writeGroupPolicyFile writes the decision policy file of the group commands to the node, and returns its path.
+func (tn *ChainNode) writeGroupPolicyFile(ctx context.Context, policy group.DecisionPolicy) (string, error) {
+	policyJSON, err := tn.Chain.Config().EncodingConfig.Codec.MarshalInterfaceJSON(policy)
+	if err != nil {
+		return "", err
+	}
+
+	file := "group_policy_" + dockerutil.RandLowerCaseLetterString(4) + ".json"
+	if err := tn.WriteFile(ctx, policyJSON, file); err != nil {
+		return "", fmt.Errorf("writing group decision policy: %w", err)
+	}
+	return path.Join(tn.HomeDir(), file), nil
+}
+
+// groupEventValue returns the value of an attribute of a typed x/group event emitted by the transaction.
+func (tn *ChainNode) groupEventValue(txHash, eventType, attrKey string) (string, error) {
+	txResp, err := tn.GetTransaction(tn.CliContext(), txHash)
+	if err != nil {
+		return "", fmt.Errorf("failed to get transaction %s: %w", txHash, err)
+	}
+
+	value, ok := tendermint.AttributeValue(txResp.Events, eventType, attrKey)
+	if !ok {
+		return "", fmt.Errorf("no %s attribute in %s event of transaction %s", attrKey, eventType, txHash)
+	}
+
+	// Typed events encode their attribute values as JSON.
+	var s string
+	if err := json.Unmarshal([]byte(value), &s); err != nil {
+		return value, nil
+	}
+	return s, nil
+}
+
+// groupEventID returns the value of an ID attribute of a typed x/group event emitted by the transaction.
+func (tn *ChainNode) groupEventID(txHash, eventType, attrKey string) (uint64, error) {
+	value, err := tn.groupEventValue(txHash, eventType, attrKey)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(value, 10, 64)
+}
+
+func groupExec(exec bool) group.Exec {
+	if exec {
+		return group.Exec_EXEC_TRY
+	}
+	return group.Exec_EXEC_UNSPECIFIED
+}
+
+// BuildGroupProposal builds a group proposal of the policy with the given messages.
+func (c *CosmosChain) BuildGroupProposal(policyAddress string, messages []ProtoMessage, title, summary, metadata string, proposers ...string) (GroupProposal, error) {
+	rawMsgs := make([]json.RawMessage, len(messages))
+	for i, msg := range messages {
+		raw, err := c.Config().EncodingConfig.Codec.MarshalInterfaceJSON(msg)
+		if err != nil {
+			return GroupProposal{}, err
+		}
+		rawMsgs[i] = raw
+	}
+
+	return GroupProposal{
+		GroupPolicyAddress: policyAddress,
+		Messages:           rawMsgs,
+		Metadata:           metadata,
+		Proposers:          proposers,
+		Title:              title,
+		Summary:            summary,
+	}, nil
+}
+
+// GroupQueryGroupInfo returns the details of a group.
+func (c *CosmosChain) GroupQueryGroupInfo(ctx context.Context, groupID uint64) (*group.GroupInfo, error) {
+	res, err := group.NewQueryClient(c.GetNode().GrpcConn).GroupInfo(ctx, &group.QueryGroupInfoRequest{GroupId: groupID})
+	if err != nil {
+		return nil, err
+	}
+	return res.Info, nil
+}
+
+// GroupQueryGroupMembers returns the members of a group.
+func (c *CosmosChain) GroupQueryGroupMembers(ctx context.Context, groupID uint64) ([]*group.GroupMember, error) {
+	res, err := group.NewQueryClient(c.GetNode().GrpcConn).GroupMembers(ctx, &group.QueryGroupMembersRequest{GroupId: groupID})
+	if err != nil {
+		return nil, err
+	}
+	return res.Members, nil
+}
+
+// GroupQueryGroupsByAdmin returns the groups administered by an account.
+func (c *CosmosChain) GroupQueryGroupsByAdmin(ctx context.Context, admin string) ([]*group.GroupInfo, error) {
+	res, err := group.NewQueryClient(c.GetNode().GrpcConn).GroupsByAdmin(ctx, &group.QueryGroupsByAdminRequest{Admin: admin})
+	if err != nil {
+		return nil, err
+	}
+	return res.Groups, nil
+}
+
+// GroupQueryGroupsByMember returns the groups an account is a member of.
+func (c *CosmosChain) GroupQueryGroupsByMember(ctx context.Context, member string) ([]*group.GroupInfo, error) {
+	res, err := group.NewQueryClient(c.GetNode().GrpcConn).GroupsByMember(ctx, &group.QueryGroupsByMemberRequest{Address: member})
+	if err != nil {
+		return nil, err
+	}
+	return res.Groups, nil
+}
+
+// GroupQueryGroupPolicyInfo returns the details of a group policy.
+func (c *CosmosChain) GroupQueryGroupPolicyInfo(ctx context.Context, policyAddress string) (*group.GroupPolicyInfo, error) {
+	res, err := group.NewQueryClient(c.GetNode().GrpcConn).GroupPolicyInfo(ctx, &group.QueryGroupPolicyInfoRequest{Address: policyAddress})
+	if err != nil {
+		return nil, err
+	}
+	return res.Info, nil
+}
+
+// GroupQueryGroupPoliciesByGroup returns the policies of a group.
+func (c *CosmosChain) GroupQueryGroupPoliciesByGroup(ctx context.Context, groupID uint64) ([]*group.GroupPolicyInfo, error) {
+	res, err := group.NewQueryClient(c.GetNode().GrpcConn).GroupPoliciesByGroup(ctx, &group.QueryGroupPoliciesByGroupRequest{GroupId: groupID})
+	if err != nil {
+		return nil, err
+	}
+	return res.GroupPolicies, nil
+}
+
+// GroupQueryGroupPoliciesByAdmin returns the group policies administered by an account.
+func (c *CosmosChain) GroupQueryGroupPoliciesByAdmin(ctx context.Context, admin string) ([]*group.GroupPolicyInfo, error) {
+	res, err := group.NewQueryClient(c.GetNode().GrpcConn).GroupPoliciesByAdmin(ctx, &group.QueryGroupPoliciesByAdminRequest{Admin: admin})
+	if err != nil {
+		return nil, err
+	}
+	return res.GroupPolicies, nil
+}
+
+// GroupQueryProposal returns the state and details of a group proposal.
+func (c *CosmosChain) GroupQueryProposal(ctx context.Context, proposalID uint64) (*group.Proposal, error) {
+	res, err := group.NewQueryClient(c.GetNode().GrpcConn).Proposal(ctx, &group.QueryProposalRequest{ProposalId: proposalID})
+	if err != nil {
+		return nil, err
+	}
+	return res.Proposal, nil
+}
+
+// GroupQueryProposalsByGroupPolicy returns the proposals of a group policy.
+func (c *CosmosChain) GroupQueryProposalsByGroupPolicy(ctx context.Context, policyAddress string) ([]*group.Proposal, error) {
+	res, err := group.NewQueryClient(c.GetNode().GrpcConn).ProposalsByGroupPolicy(ctx, &group.QueryProposalsByGroupPolicyRequest{Address: policyAddress})
+	if err != nil {
+		return nil, err
+	}
+	return res.Proposals, nil
+}
+
+// GroupQueryVote returns the vote of a voter on a group proposal.
+func (c *CosmosChain) GroupQueryVote(ctx context.Context, proposalID uint64, voter string) (*group.Vote, error) {
+	res, err := group.NewQueryClient(c.GetNode().GrpcConn).VoteByProposalVoter(ctx, &group.QueryVoteByProposalVoterRequest{
+		ProposalId: proposalID,
+		Voter:      voter,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.Vote, nil
+}
+
+// GroupQueryVotesByProposal returns the votes on a group proposal.
+func (c *CosmosChain) GroupQueryVotesByProposal(ctx context.Context, proposalID uint64) ([]*group.Vote, error) {
+	res, err := group.NewQueryClient(c.GetNode().GrpcConn).VotesByProposal(ctx, &group.QueryVotesByProposalRequest{ProposalId: proposalID})
+	if err != nil {
+		return nil, err
+	}
+	return res.Votes, nil
+}
+
+// GroupQueryVotesByVoter returns the votes of a voter on group proposals.
+func (c *CosmosChain) GroupQueryVotesByVoter(ctx context.Context, voter string) ([]*group.Vote, error) {
+	res, err := group.NewQueryClient(c.GetNode().GrpcConn).VotesByVoter(ctx, &group.QueryVotesByVoterRequest{Voter: voter})
+	if err != nil {
+		return nil, err
+	}
+	return res.Votes, nil
+}
+
+// GroupQueryTally returns the tally of the votes on a group proposal.
+// Proposals that are no longer open for voting store their final tally, see GroupQueryProposal.
+func (c *CosmosChain) GroupQueryTally(ctx context.Context, proposalID uint64) (*group.TallyResult, error) {
+	res, err := group.NewQueryClient(c.GetNode().GrpcConn).TallyResult(ctx, &group.QueryTallyResultRequest{ProposalId: proposalID})
+	if err != nil {
+		return nil, err
+	}
+	return &res.Tally, nil
+}
@@ -0,0 +1,254 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/cosmos/gogoproto/proto"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	icacontrollertypes "github.com/cosmos/ibc-go/v8/modules/apps/27-interchain-accounts/controller/types"
+	icahosttypes "github.com/cosmos/ibc-go/v8/modules/apps/27-interchain-accounts/host/types"
+	icatypes "github.com/cosmos/ibc-go/v8/modules/apps/27-interchain-accounts/types"
+	chantypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+
+	"github.com/strangelove-ventures/interchaintest/v8/chain/internal/tendermint"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/strangelove-ventures/interchaintest/v8/testutil"
+)
+
+// ICARegisterOptions configures the channel of an interchain account registered with ICARegister.
+type ICARegisterOptions struct {
+	// Version is the version of the channel, i.e. the JSON encoded ICS-27 metadata.
+	// The controller module uses the default metadata of the connection if it is empty.
+	Version string
+
+	// Ordering is the ordering of the channel.
+	// The controller module opens an unordered channel if it is ibc.Invalid, the zero value.
+	Ordering ibc.Order
+}
+
+// ICAAck is the acknowledgement of an interchain account transaction, written by the host chain.
+type ICAAck struct {
+	Packet ibc.Packet
+
+	// Error is the error of the host chain if the transaction failed.
+	// ibc-go only acknowledges the ABCI code of the failure, the details are in the events of the host chain.
+	Error string
+
+	// MsgResponses are the responses to the messages of the transaction, in order, if it succeeded.
+	MsgResponses []*codectypes.Any
+}
+
+// Success reports whether the host chain executed the transaction successfully.
+func (a ICAAck) Success() bool {
+	return a.Error == ""
+}
+
+// UnpackMsgResponse unmarshals the response to the i-th message of the transaction into res.
+func (a ICAAck) UnpackMsgResponse(i int, res proto.Message) error {
+	if i < 0 || i >= len(a.MsgResponses) {
+		return fmt.Errorf("no response to message %d, the transaction has %d responses", i, len(a.MsgResponses))
+	}
+	return proto.Unmarshal(a.MsgResponses[i].Value, res)
+}
+
+// NewICAPacketData builds the packet data of an interchain account transaction executing msgs on the host chain.
+// The msgs are serialized with cdc in the given encoding, icatypes.EncodingProtobuf or icatypes.EncodingProto3JSON,
+// which must be supported by the host chain.
+func NewICAPacketData(cdc codec.Codec, encoding, memo string, msgs ...sdk.Msg) (icatypes.InterchainAccountPacketData, error) {
+	data, err := icatypes.SerializeCosmosTx(cdc, msgs, encoding)
+	if err != nil {
+		return icatypes.InterchainAccountPacketData{}, err
+	}
+
+	packetData := icatypes.InterchainAccountPacketData{
+		Type: icatypes.EXECUTE_TX,
+		Data: data,
+		Memo: memo,
+	}
+	return packetData, packetData.ValidateBasic()
+}
+
+// ICARegister registers an interchain account owned by keyName on the host chain of the connection.
+// The registration completes once a relayer finished the handshake of the channel of the account.
+func (tn *ChainNode) ICARegister(ctx context.Context, keyName, connectionID string, opts ICARegisterOptions) (string, error) {
+	var ordering chantypes.Order
+	switch opts.Ordering {
+	case ibc.Ordered:
+		ordering = chantypes.ORDERED
+	case ibc.Unordered:
+		ordering = chantypes.UNORDERED
+	}
+
+	if tn.UsesGRPCTx() {
+		owner, err := tn.KeyAddress(ctx, keyName)
+		if err != nil {
+			return "", err
+		}
+		return tn.BroadcastMsgs(ctx, keyName, &icacontrollertypes.MsgRegisterInterchainAccount{
+			Owner:        owner,
+			ConnectionId: connectionID,
+			Version:      opts.Version,
+			Ordering:     ordering,
+		})
+	}
+
+	cmd := []string{"interchain-accounts", "controller", "register", connectionID}
+	if opts.Version != "" {
+		cmd = append(cmd, "--version", opts.Version)
+	}
+	if ordering != chantypes.NONE {
+		cmd = append(cmd, "--ordering", ordering.String())
+	}
+	return tn.ExecTx(ctx, keyName, cmd...)
+}
+
+// ICASendTx sends packetData to be executed by the interchain account of keyName on the host chain of the connection.
+// The packet times out after timeout, measured from the block time of the controller chain,
+// or after icatypes.DefaultRelativePacketTimeoutTimestamp if timeout is 0.
+func (tn *ChainNode) ICASendTx(ctx context.Context, keyName, connectionID string, packetData icatypes.InterchainAccountPacketData, timeout time.Duration) (string, error) {
+	relativeTimeout := uint64(timeout.Nanoseconds())
+	if relativeTimeout == 0 {
+		relativeTimeout = icatypes.DefaultRelativePacketTimeoutTimestamp
+	}
+
+	if tn.UsesGRPCTx() {
+		owner, err := tn.KeyAddress(ctx, keyName)
+		if err != nil {
+			return "", err
+		}
+		return tn.BroadcastMsgs(ctx, keyName, &icacontrollertypes.MsgSendTx{
+			Owner:           owner,
+			ConnectionId:    connectionID,
+			PacketData:      packetData,
+			RelativeTimeout: relativeTimeout,
+		})
+	}
+
+	bz, err := tn.Chain.Config().EncodingConfig.Codec.MarshalJSON(&packetData)
+	if err != nil {
+		return "", err
+	}
+	return tn.ExecTx(ctx, keyName,
+		"interchain-accounts", "controller", "send-tx", connectionID, string(bz),
+		"--relative-packet-timeout", strconv.FormatUint(relativeTimeout, 10),
+	)
+}
+
+// ICARegister registers an interchain account owned by keyName on the host chain of the connection,
+// and returns the controller end of the channel of the account, whose handshake must be completed by a relayer.
+func (c *CosmosChain) ICARegister(ctx context.Context, keyName, connectionID string, opts ICARegisterOptions) (ibc.ChannelOutput, error) {
+	var ch ibc.ChannelOutput
+
+	txHash, err := c.GetFullNode().ICARegister(ctx, keyName, connectionID, opts)
+	if err != nil {
+		return ch, fmt.Errorf("register interchain account: %w", err)
+	}
+	txResp, err := c.GetTransaction(txHash)
+	if err != nil {
+		return ch, fmt.Errorf("failed to get transaction %s: %w", txHash, err)
+	}
+	if txResp.Code != 0 {
+		return ch, fmt.Errorf("error in transaction (code: %d): %s", txResp.Code, txResp.RawLog)
+	}
+
+	const evType = "channel_open_init"
+	ch.State = chantypes.INIT.String()
+	ch.Ordering = chantypes.UNORDERED.String()
+	if opts.Ordering == ibc.Ordered {
+		ch.Ordering = chantypes.ORDERED.String()
+	}
+	ch.PortID, _ = tendermint.AttributeValue(txResp.Events, evType, "port_id")
+	ch.ChannelID, _ = tendermint.AttributeValue(txResp.Events, evType, "channel_id")
+	ch.Counterparty.PortID, _ = tendermint.AttributeValue(txResp.Events, evType, "counterparty_port_id")
+	ch.ConnectionHops = []string{connectionID}
+	ch.Version, _ = tendermint.AttributeValue(txResp.Events, evType, "version")
+	if ch.ChannelID == "" {
+		return ch, fmt.Errorf("no %s event in transaction %s", evType, txHash)
+	}
+	return ch, nil
+}
+
+// ICAExecute sends msgs to be executed by the interchain account of keyName on the host chain of the connection,
+// serialized with the codec of the chain in icatypes.EncodingProtobuf.
+// The returned transaction holds the packet to pass to ICAWaitForAck.
+// See ICASendTx for the timeout.
+func (c *CosmosChain) ICAExecute(ctx context.Context, keyName, connectionID, memo string, timeout time.Duration, msgs ...sdk.Msg) (ibc.Tx, error) {
+	packetData, err := NewICAPacketData(c.Config().EncodingConfig.Codec, icatypes.EncodingProtobuf, memo, msgs...)
+	if err != nil {
+		return ibc.Tx{}, err
+	}
+	txHash, err := c.GetFullNode().ICASendTx(ctx, keyName, connectionID, packetData, timeout)
+	if err != nil {
+		return ibc.Tx{}, fmt.Errorf("send interchain account tx: %w", err)
+	}
+	return c.packetTx(txHash)
+}
+
+// ICAWaitForAck waits for the acknowledgement of the packet of an interchain account transaction sent with ICAExecute,
+// searching at most maxBlocks blocks of the chain from the height of the transaction,
+// and returns the result of the transaction on the host chain.
+// The acknowledgement is read from the MsgAcknowledgement that a relayer submits to the chain.
+func (c *CosmosChain) ICAWaitForAck(ctx context.Context, tx ibc.Tx, maxBlocks int64) (ICAAck, error) {
+	found, err := testutil.PollForAck(ctx, c, tx.Height, tx.Height+maxBlocks, tx.Packet)
+	if err != nil {
+		return ICAAck{Packet: tx.Packet}, err
+	}
+	return parseICAAck(found)
+}
+
+// parseICAAck decodes the acknowledgement of an interchain account transaction.
+func parseICAAck(found ibc.PacketAcknowledgement) (ICAAck, error) {
+	ack := ICAAck{Packet: found.Packet}
+
+	var chanAck chantypes.Acknowledgement
+	if err := chantypes.SubModuleCdc.UnmarshalJSON(found.Acknowledgement, &chanAck); err != nil {
+		return ack, fmt.Errorf("malformed acknowledgement %s: %w", found.Acknowledgement, err)
+	}
+	if !chanAck.Success() {
+		ack.Error = chanAck.GetError()
+		return ack, nil
+	}
+
+	var txMsgData sdk.TxMsgData
+	if err := proto.Unmarshal(chanAck.GetResult(), &txMsgData); err != nil {
+		return ack, fmt.Errorf("malformed interchain account tx result: %w", err)
+	}
+	ack.MsgResponses = txMsgData.MsgResponses
+	return ack, nil
+}
+
+// ICAQueryAddress returns the address of the interchain account of owner on the host chain of the connection.
+func (c *CosmosChain) ICAQueryAddress(ctx context.Context, owner, connectionID string) (string, error) {
+	res, err := icacontrollertypes.NewQueryClient(c.GetNode().GrpcConn).InterchainAccount(ctx, &icacontrollertypes.QueryInterchainAccountRequest{
+		Owner:        owner,
+		ConnectionId: connectionID,
+	})
+	if err != nil {
+		return "", err
+	}
+	return res.Address, nil
+}
+
+// ICAQueryControllerParams returns the params of the interchain accounts controller module.
+func (c *CosmosChain) ICAQueryControllerParams(ctx context.Context) (*icacontrollertypes.Params, error) {
+	res, err := icacontrollertypes.NewQueryClient(c.GetNode().GrpcConn).Params(ctx, &icacontrollertypes.QueryParamsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return res.Params, nil
+}
+
+// ICAQueryHostParams returns the params of the interchain accounts host module.
+func (c *CosmosChain) ICAQueryHostParams(ctx context.Context) (*icahosttypes.Params, error) {
+	res, err := icahosttypes.NewQueryClient(c.GetNode().GrpcConn).Params(ctx, &icahosttypes.QueryParamsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return res.Params, nil
+}
@@ -0,0 +1,83 @@
+package cosmos
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/stretchr/testify/require"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	icatypes "github.com/cosmos/ibc-go/v8/modules/apps/27-interchain-accounts/types"
+	chantypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+)
+
+func TestNewICAPacketData(t *testing.T) {
+	cdc := DefaultEncoding().Codec
+	msg := &banktypes.MsgSend{
+		FromAddress: "cosmos1from",
+		ToAddress:   "cosmos1to",
+		Amount:      sdk.NewCoins(sdk.NewInt64Coin("stake", 1)),
+	}
+
+	for _, encoding := range []string{icatypes.EncodingProtobuf, icatypes.EncodingProto3JSON} {
+		t.Run(encoding, func(t *testing.T) {
+			packetData, err := NewICAPacketData(cdc, encoding, "memo", msg)
+			require.NoError(t, err)
+			require.Equal(t, icatypes.EXECUTE_TX, packetData.Type)
+			require.Equal(t, "memo", packetData.Memo)
+
+			msgs, err := icatypes.DeserializeCosmosTx(cdc, packetData.Data, encoding)
+			require.NoError(t, err)
+			require.Len(t, msgs, 1)
+			require.Equal(t, msg.ToAddress, msgs[0].(*banktypes.MsgSend).ToAddress)
+		})
+	}
+
+	_, err := NewICAPacketData(cdc, icatypes.EncodingProtobuf, "")
+	require.Error(t, err, "packet data without messages")
+}
+
+func TestParseICAAck(t *testing.T) {
+	packet := ibc.Packet{Sequence: 1, SourcePort: "icacontroller-owner", SourceChannel: "channel-1"}
+
+	t.Run("success", func(t *testing.T) {
+		res, err := codectypes.NewAnyWithValue(&banktypes.MsgSendResponse{})
+		require.NoError(t, err)
+		result, err := proto.Marshal(&sdk.TxMsgData{MsgResponses: []*codectypes.Any{res}})
+		require.NoError(t, err)
+
+		ack, err := parseICAAck(ibc.PacketAcknowledgement{
+			Packet:          packet,
+			Acknowledgement: chantypes.NewResultAcknowledgement(result).Acknowledgement(),
+		})
+		require.NoError(t, err)
+		require.True(t, ack.Success())
+		require.Equal(t, packet, ack.Packet)
+		require.Len(t, ack.MsgResponses, 1)
+
+		var sendRes banktypes.MsgSendResponse
+		require.NoError(t, ack.UnpackMsgResponse(0, &sendRes))
+		require.Error(t, ack.UnpackMsgResponse(1, &sendRes))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		ack, err := parseICAAck(ibc.PacketAcknowledgement{
+			Packet:          packet,
+			Acknowledgement: chantypes.NewErrorAcknowledgement(errors.New("failed")).Acknowledgement(),
+		})
+		require.NoError(t, err)
+		require.False(t, ack.Success())
+		require.NotEmpty(t, ack.Error)
+		require.Empty(t, ack.MsgResponses)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		_, err := parseICAAck(ibc.PacketAcknowledgement{Packet: packet, Acknowledgement: []byte("ack")})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,168 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cosmos/gogoproto/proto"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	chantypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/strangelove-ventures/interchaintest/v8/testutil"
+)
+
+const (
+	// ICQHostPort is the port of the interchain queries host module (async-icq).
+	ICQHostPort = "icqhost"
+
+	// ICQVersion is the version of interchain queries channels.
+	ICQVersion = "icq-1"
+)
+
+// The messages of async-icq are encoded by hand, to not depend on the module for four messages.
+// The packet data and the acknowledgement result are JSON encoded, their data is a protobuf encoded
+// CosmosQuery or CosmosResponse, which only have a repeated field 1 of abci.RequestQuery or abci.ResponseQuery.
+const icqRepeatedField protowire.Number = 1
+
+// icqPacketData is the JSON encoding of the async-icq InterchainQueryPacketData.
+type icqPacketData struct {
+	Data []byte `json:"data"`
+	Memo string `json:"memo,omitempty"`
+}
+
+// icqPacketAck is the JSON encoding of the async-icq InterchainQueryPacketAck.
+type icqPacketAck struct {
+	Data []byte `json:"data"`
+}
+
+// ICQAck is the acknowledgement of an interchain query, written by the host chain.
+type ICQAck struct {
+	Packet ibc.Packet
+
+	// Error is the error of the host chain if it did not answer the queries.
+	Error string
+
+	// Responses are the responses to the queries of the packet, in order, if the host chain answered them.
+	Responses []abci.ResponseQuery
+}
+
+// Success reports whether the host chain answered the queries.
+// Each query may still have failed, see UnpackResponse.
+func (a ICQAck) Success() bool {
+	return a.Error == ""
+}
+
+// UnpackResponse unmarshals the response to the i-th query of the packet into res,
+// or returns an error if the host chain failed to answer the query.
+func (a ICQAck) UnpackResponse(i int, res proto.Message) error {
+	if i < 0 || i >= len(a.Responses) {
+		return fmt.Errorf("no response to query %d, the packet has %d responses", i, len(a.Responses))
+	}
+	r := a.Responses[i]
+	if r.Code != 0 {
+		return fmt.Errorf("query %d failed with code %d: %s", i, r.Code, r.Log)
+	}
+	return proto.Unmarshal(r.Value, res)
+}
+
+// NewICQRequest builds a query of the gRPC method path of the host chain with req,
+// e.g. "/cosmos.bank.v1beta1.Query/AllBalances" with a banktypes.QueryAllBalancesRequest.
+// The host chain must allow the path in its params.
+func NewICQRequest(path string, req proto.Message) (abci.RequestQuery, error) {
+	data, err := proto.Marshal(req)
+	if err != nil {
+		return abci.RequestQuery{}, err
+	}
+	return abci.RequestQuery{Path: path, Data: data}, nil
+}
+
+// NewICQPacketData builds the packet data of an interchain query of requests,
+// i.e. a JSON encoded InterchainQueryPacketData wrapping a CosmosQuery.
+func NewICQPacketData(memo string, requests ...abci.RequestQuery) ([]byte, error) {
+	if len(requests) == 0 {
+		return nil, fmt.Errorf("no interchain queries")
+	}
+
+	var query []byte
+	for i := range requests {
+		bz, err := requests[i].Marshal()
+		if err != nil {
+			return nil, err
+		}
+		query = protowire.AppendTag(query, icqRepeatedField, protowire.BytesType)
+		query = protowire.AppendBytes(query, bz)
+	}
+	return json.Marshal(icqPacketData{Data: query, Memo: memo})
+}
+
+// ParseICQAck decodes the acknowledgement of an interchain query,
+// e.g. an acknowledgement returned by testutil.PollForAck.
+func ParseICQAck(found ibc.PacketAcknowledgement) (ICQAck, error) {
+	ack := ICQAck{Packet: found.Packet}
+
+	var chanAck chantypes.Acknowledgement
+	if err := chantypes.SubModuleCdc.UnmarshalJSON(found.Acknowledgement, &chanAck); err != nil {
+		return ack, fmt.Errorf("malformed acknowledgement %s: %w", found.Acknowledgement, err)
+	}
+	if !chanAck.Success() {
+		ack.Error = chanAck.GetError()
+		return ack, nil
+	}
+
+	var packetAck icqPacketAck
+	if err := json.Unmarshal(chanAck.GetResult(), &packetAck); err != nil {
+		return ack, fmt.Errorf("malformed interchain query acknowledgement %s: %w", chanAck.GetResult(), err)
+	}
+
+	for bz := packetAck.Data; len(bz) > 0; {
+		num, typ, n := protowire.ConsumeTag(bz)
+		if n < 0 {
+			return ack, fmt.Errorf("malformed interchain query response: %w", protowire.ParseError(n))
+		}
+		bz = bz[n:]
+
+		if num != icqRepeatedField || typ != protowire.BytesType {
+			// Skip unknown fields, as protobuf does.
+			n = protowire.ConsumeFieldValue(num, typ, bz)
+			if n < 0 {
+				return ack, fmt.Errorf("malformed interchain query response: %w", protowire.ParseError(n))
+			}
+			bz = bz[n:]
+			continue
+		}
+
+		v, n := protowire.ConsumeBytes(bz)
+		if n < 0 {
+			return ack, fmt.Errorf("malformed interchain query response: %w", protowire.ParseError(n))
+		}
+		bz = bz[n:]
+
+		var res abci.ResponseQuery
+		if err := res.Unmarshal(v); err != nil {
+			return ack, fmt.Errorf("malformed interchain query response: %w", err)
+		}
+		ack.Responses = append(ack.Responses, res)
+	}
+	return ack, nil
+}
+
+// ICQWaitForAck waits for the acknowledgement of the interchain query sent by the transaction txHash,
+// e.g. the execution of a contract sending queries over an ICQHostPort channel,
+// searching at most maxBlocks blocks of the chain from the height of the transaction.
+// The acknowledgement is read from the MsgAcknowledgement that a relayer submits to the chain.
+func (c *CosmosChain) ICQWaitForAck(ctx context.Context, txHash string, maxBlocks int64) (ICQAck, error) {
+	tx, err := c.packetTx(txHash)
+	if err != nil {
+		return ICQAck{}, err
+	}
+
+	found, err := testutil.PollForAck(ctx, c, tx.Height, tx.Height+maxBlocks, tx.Packet)
+	if err != nil {
+		return ICQAck{Packet: tx.Packet}, err
+	}
+	return ParseICQAck(found)
+}
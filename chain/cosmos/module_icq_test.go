@@ -0,0 +1,117 @@
+package cosmos
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	chantypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+)
+
+const allBalancesPath = "/cosmos.bank.v1beta1.Query/AllBalances"
+
+func TestNewICQPacketData(t *testing.T) {
+	req, err := NewICQRequest(allBalancesPath, &banktypes.QueryAllBalancesRequest{Address: "cosmos1addr"})
+	require.NoError(t, err)
+
+	bz, err := NewICQPacketData("memo", req, req)
+	require.NoError(t, err)
+
+	var packetData icqPacketData
+	require.NoError(t, json.Unmarshal(bz, &packetData))
+	require.Equal(t, "memo", packetData.Memo)
+
+	// The data is a CosmosQuery with both requests.
+	var requests []abci.RequestQuery
+	for data := packetData.Data; len(data) > 0; {
+		num, typ, n := protowire.ConsumeTag(data)
+		require.GreaterOrEqual(t, n, 0)
+		require.Equal(t, icqRepeatedField, num)
+		require.Equal(t, protowire.BytesType, typ)
+		data = data[n:]
+
+		v, n := protowire.ConsumeBytes(data)
+		require.GreaterOrEqual(t, n, 0)
+		data = data[n:]
+
+		var r abci.RequestQuery
+		require.NoError(t, r.Unmarshal(v))
+		requests = append(requests, r)
+	}
+	require.Equal(t, []abci.RequestQuery{req, req}, requests)
+
+	var balancesReq banktypes.QueryAllBalancesRequest
+	require.NoError(t, proto.Unmarshal(requests[0].Data, &balancesReq))
+	require.Equal(t, "cosmos1addr", balancesReq.Address)
+
+	_, err = NewICQPacketData("")
+	require.Error(t, err, "packet data without queries")
+}
+
+func TestParseICQAck(t *testing.T) {
+	packet := ibc.Packet{Sequence: 1, SourcePort: "wasm.contract", SourceChannel: "channel-1", DestPort: ICQHostPort}
+
+	t.Run("success", func(t *testing.T) {
+		balances := &banktypes.QueryAllBalancesResponse{Balances: sdk.NewCoins(sdk.NewInt64Coin("stake", 5))}
+		value, err := proto.Marshal(balances)
+		require.NoError(t, err)
+
+		var response []byte
+		for _, r := range []abci.ResponseQuery{{Value: value}, {Code: 1, Log: "not allowed"}} {
+			bz, err := r.Marshal()
+			require.NoError(t, err)
+			response = protowire.AppendTag(response, icqRepeatedField, protowire.BytesType)
+			response = protowire.AppendBytes(response, bz)
+		}
+		result, err := json.Marshal(icqPacketAck{Data: response})
+		require.NoError(t, err)
+
+		ack, err := ParseICQAck(ibc.PacketAcknowledgement{
+			Packet:          packet,
+			Acknowledgement: chantypes.NewResultAcknowledgement(result).Acknowledgement(),
+		})
+		require.NoError(t, err)
+		require.True(t, ack.Success())
+		require.Equal(t, packet, ack.Packet)
+		require.Len(t, ack.Responses, 2)
+
+		var res banktypes.QueryAllBalancesResponse
+		require.NoError(t, ack.UnpackResponse(0, &res))
+		require.Equal(t, balances.Balances, res.Balances)
+
+		require.ErrorContains(t, ack.UnpackResponse(1, &res), "not allowed")
+		require.Error(t, ack.UnpackResponse(2, &res))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		ack, err := ParseICQAck(ibc.PacketAcknowledgement{
+			Packet:          packet,
+			Acknowledgement: chantypes.NewErrorAcknowledgement(errors.New("failed")).Acknowledgement(),
+		})
+		require.NoError(t, err)
+		require.False(t, ack.Success())
+		require.Empty(t, ack.Responses)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		_, err := ParseICQAck(ibc.PacketAcknowledgement{Packet: packet, Acknowledgement: []byte("ack")})
+		require.Error(t, err)
+
+		result, err := json.Marshal(icqPacketAck{Data: []byte{0x0a, 0xff}})
+		require.NoError(t, err)
+		_, err = ParseICQAck(ibc.PacketAcknowledgement{
+			Packet:          packet,
+			Acknowledgement: chantypes.NewResultAcknowledgement(result).Acknowledgement(),
+		})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,90 @@
+package cosmos
+
+import (
+	"context"
+
+	"cosmossdk.io/x/nft"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// NFTSend transfers the ownership of an NFT of keyName to receiver.
+func (tn *ChainNode) NFTSend(ctx context.Context, keyName, classID, nftID, receiver string) error {
+	if tn.UsesGRPCTx() {
+		return tn.broadcastMsgsFrom(ctx, keyName, func(addr string) ([]sdk.Msg, error) {
+			return []sdk.Msg{&nft.MsgSend{
+				ClassId:  classID,
+				Id:       nftID,
+				Sender:   addr,
+				Receiver: receiver,
+			}}, nil
+		})
+	}
+
+	_, err := tn.ExecTx(ctx, keyName, "nft", "send", classID, nftID, receiver)
+	return err
+}
+
+// NFTQueryBalance returns the number of NFTs of a class owned by an account.
+func (c *CosmosChain) NFTQueryBalance(ctx context.Context, classID, owner string) (uint64, error) {
+	res, err := nft.NewQueryClient(c.GetNode().GrpcConn).Balance(ctx, &nft.QueryBalanceRequest{ClassId: classID, Owner: owner})
+	if err != nil {
+		return 0, err
+	}
+	return res.Amount, nil
+}
+
+// NFTQueryOwner returns the owner of an NFT.
+func (c *CosmosChain) NFTQueryOwner(ctx context.Context, classID, nftID string) (string, error) {
+	res, err := nft.NewQueryClient(c.GetNode().GrpcConn).Owner(ctx, &nft.QueryOwnerRequest{ClassId: classID, Id: nftID})
+	if err != nil {
+		return "", err
+	}
+	return res.Owner, nil
+}
+
+// NFTQuerySupply returns the number of NFTs of a class.
+func (c *CosmosChain) NFTQuerySupply(ctx context.Context, classID string) (uint64, error) {
+	res, err := nft.NewQueryClient(c.GetNode().GrpcConn).Supply(ctx, &nft.QuerySupplyRequest{ClassId: classID})
+	if err != nil {
+		return 0, err
+	}
+	return res.Amount, nil
+}
+
+// NFTQueryNFTs returns the NFTs of a class, of an owner, or of a class owned by an owner.
+// Either classID or owner may be empty, but not both.
+func (c *CosmosChain) NFTQueryNFTs(ctx context.Context, classID, owner string) ([]*nft.NFT, error) {
+	res, err := nft.NewQueryClient(c.GetNode().GrpcConn).NFTs(ctx, &nft.QueryNFTsRequest{ClassId: classID, Owner: owner})
+	if err != nil {
+		return nil, err
+	}
+	return res.Nfts, nil
+}
+
+// NFTQueryNFT returns an NFT.
+func (c *CosmosChain) NFTQueryNFT(ctx context.Context, classID, nftID string) (*nft.NFT, error) {
+	res, err := nft.NewQueryClient(c.GetNode().GrpcConn).NFT(ctx, &nft.QueryNFTRequest{ClassId: classID, Id: nftID})
+	if err != nil {
+		return nil, err
+	}
+	return res.Nft, nil
+}
+
+// NFTQueryClass returns an NFT class.
+func (c *CosmosChain) NFTQueryClass(ctx context.Context, classID string) (*nft.Class, error) {
+	res, err := nft.NewQueryClient(c.GetNode().GrpcConn).Class(ctx, &nft.QueryClassRequest{ClassId: classID})
+	if err != nil {
+		return nil, err
+	}
+	return res.Class, nil
+}
+
+// NFTQueryClasses returns all NFT classes.
+func (c *CosmosChain) NFTQueryClasses(ctx context.Context) ([]*nft.Class, error) {
+	res, err := nft.NewQueryClient(c.GetNode().GrpcConn).Classes(ctx, &nft.QueryClassesRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return res.Classes, nil
+}
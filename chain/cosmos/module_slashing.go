@@ -3,11 +3,27 @@ package cosmos
 import (
 	"context"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
 	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
 )
 
 // SlashingUnJail unjails a validator.
 func (tn *ChainNode) SlashingUnJail(ctx context.Context, keyName string) error {
+	if tn.UsesGRPCTx() {
+		return tn.broadcastMsgsFrom(ctx, keyName, func(addr string) ([]sdk.Msg, error) {
+			bz, err := sdk.GetFromBech32(addr, tn.Chain.Config().Bech32Prefix)
+			if err != nil {
+				return nil, err
+			}
+			valAddr, err := bech32.ConvertAndEncode(tn.Chain.Config().Bech32Prefix+"valoper", bz)
+			if err != nil {
+				return nil, err
+			}
+			return []sdk.Msg{&slashingtypes.MsgUnjail{ValidatorAddr: valAddr}}, nil
+		})
+	}
+
 	_, err := tn.ExecTx(ctx,
 		keyName, "slashing", "unjail",
 	)
@@ -2,13 +2,31 @@ package cosmos
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"path"
+	"strings"
 
+	sdkmath "cosmossdk.io/math"
+
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 )
 
 // StakingCancelUnbond cancels an unbonding delegation.
 func (tn *ChainNode) StakingCancelUnbond(ctx context.Context, keyName, validatorAddr, coinAmt string, creationHeight int64) error {
+	if tn.UsesGRPCTx() {
+		return tn.broadcastStakingMsg(ctx, keyName, coinAmt, func(delegator string, amount sdk.Coin) sdk.Msg {
+			return &stakingtypes.MsgCancelUnbondingDelegation{
+				DelegatorAddress: delegator,
+				ValidatorAddress: validatorAddr,
+				Amount:           amount,
+				CreationHeight:   creationHeight,
+			}
+		})
+	}
+
 	_, err := tn.ExecTx(ctx,
 		keyName, "staking", "cancel-unbond", validatorAddr, coinAmt, fmt.Sprintf("%d", creationHeight),
 	)
@@ -16,7 +34,12 @@ func (tn *ChainNode) StakingCancelUnbond(ctx context.Context, keyName, validator
 }
 
 // StakingCreateValidator creates a new validator.
+// valFilePath is the path of the validator file in the node container, see StakingCreateValidatorFile.
 func (tn *ChainNode) StakingCreateValidator(ctx context.Context, keyName, valFilePath string) error {
+	if tn.UsesGRPCTx() {
+		return tn.broadcastCreateValidator(ctx, keyName, valFilePath)
+	}
+
 	_, err := tn.ExecTx(ctx,
 		keyName, "staking", "create-validator", valFilePath,
 	)
@@ -25,6 +48,12 @@ func (tn *ChainNode) StakingCreateValidator(ctx context.Context, keyName, valFil
 
 // StakingDelegate delegates tokens to a validator.
 func (tn *ChainNode) StakingDelegate(ctx context.Context, keyName, validatorAddr, amount string) error {
+	if tn.UsesGRPCTx() {
+		return tn.broadcastStakingMsg(ctx, keyName, amount, func(delegator string, amount sdk.Coin) sdk.Msg {
+			return &stakingtypes.MsgDelegate{DelegatorAddress: delegator, ValidatorAddress: validatorAddr, Amount: amount}
+		})
+	}
+
 	_, err := tn.ExecTx(ctx,
 		keyName, "staking", "delegate", validatorAddr, amount,
 	)
@@ -33,6 +62,12 @@ func (tn *ChainNode) StakingDelegate(ctx context.Context, keyName, validatorAddr
 
 // StakingUnbond unstakes tokens from a validator.
 func (tn *ChainNode) StakingUnbond(ctx context.Context, keyName, validatorAddr, amount string) error {
+	if tn.UsesGRPCTx() {
+		return tn.broadcastStakingMsg(ctx, keyName, amount, func(delegator string, amount sdk.Coin) sdk.Msg {
+			return &stakingtypes.MsgUndelegate{DelegatorAddress: delegator, ValidatorAddress: validatorAddr, Amount: amount}
+		})
+	}
+
 	_, err := tn.ExecTx(ctx,
 		keyName, "staking", "unbond", validatorAddr, amount,
 	)
@@ -41,6 +76,10 @@ func (tn *ChainNode) StakingUnbond(ctx context.Context, keyName, validatorAddr,
 
 // StakingEditValidator edits an existing validator.
 func (tn *ChainNode) StakingEditValidator(ctx context.Context, keyName string, flags ...string) error {
+	if tn.UsesGRPCTx() {
+		return tn.broadcastEditValidator(ctx, keyName, flags)
+	}
+
 	cmd := []string{"staking", "edit-validator"}
 	cmd = append(cmd, flags...)
 
@@ -52,12 +91,142 @@ func (tn *ChainNode) StakingEditValidator(ctx context.Context, keyName string, f
 
 // StakingRedelegate redelegates tokens from one validator to another.
 func (tn *ChainNode) StakingRedelegate(ctx context.Context, keyName, srcValAddr, dstValAddr, amount string) error {
+	if tn.UsesGRPCTx() {
+		return tn.broadcastStakingMsg(ctx, keyName, amount, func(delegator string, amount sdk.Coin) sdk.Msg {
+			return &stakingtypes.MsgBeginRedelegate{
+				DelegatorAddress:    delegator,
+				ValidatorSrcAddress: srcValAddr,
+				ValidatorDstAddress: dstValAddr,
+				Amount:              amount,
+			}
+		})
+	}
+
 	_, err := tn.ExecTx(ctx,
 		keyName, "staking", "redelegate", srcValAddr, dstValAddr, amount,
 	)
 	return err
 }
 
+// broadcastCreateValidator is the gRPC counterpart of the staking create-validator command run by StakingCreateValidator.
+func (tn *ChainNode) broadcastCreateValidator(ctx context.Context, keyName, valFilePath string) error {
+	relPath := valFilePath
+	if path.IsAbs(valFilePath) {
+		var ok bool
+		if relPath, ok = strings.CutPrefix(valFilePath, tn.HomeDir()+"/"); !ok {
+			return fmt.Errorf("validator file %s is not in the home directory %s", valFilePath, tn.HomeDir())
+		}
+	}
+	bz, err := tn.ReadFile(ctx, relPath)
+	if err != nil {
+		return err
+	}
+
+	var v struct {
+		Amount              string          `json:"amount"`
+		PubKey              json.RawMessage `json:"pubkey"`
+		Moniker             string          `json:"moniker"`
+		Identity            string          `json:"identity"`
+		Website             string          `json:"website"`
+		Security            string          `json:"security"`
+		Details             string          `json:"details"`
+		CommissionRate      string          `json:"commission-rate"`
+		CommissionMaxRate   string          `json:"commission-max-rate"`
+		CommissionMaxChange string          `json:"commission-max-change-rate"`
+		MinSelfDelegation   string          `json:"min-self-delegation"`
+	}
+	if err := json.Unmarshal(bz, &v); err != nil {
+		return fmt.Errorf("failed to parse validator file %s: %w", valFilePath, err)
+	}
+
+	amount, err := sdk.ParseCoinNormalized(v.Amount)
+	if err != nil {
+		return err
+	}
+	var pubKey cryptotypes.PubKey
+	if err := tn.Chain.Config().EncodingConfig.Codec.UnmarshalInterfaceJSON(v.PubKey, &pubKey); err != nil {
+		return fmt.Errorf("failed to decode validator pubkey: %w", err)
+	}
+	var rates [3]sdkmath.LegacyDec
+	for i, rate := range []string{v.CommissionRate, v.CommissionMaxRate, v.CommissionMaxChange} {
+		if rates[i], err = sdkmath.LegacyNewDecFromStr(rate); err != nil {
+			return fmt.Errorf("invalid commission rate %q: %w", rate, err)
+		}
+	}
+	minSelfDelegation, ok := sdkmath.NewIntFromString(v.MinSelfDelegation)
+	if !ok {
+		return fmt.Errorf("invalid minimum self delegation %q", v.MinSelfDelegation)
+	}
+
+	valoper, err := tn.KeyBech32(ctx, keyName, "val")
+	if err != nil {
+		return err
+	}
+	msg, err := stakingtypes.NewMsgCreateValidator(
+		valoper, pubKey, amount,
+		stakingtypes.NewDescription(v.Moniker, v.Identity, v.Website, v.Security, v.Details),
+		stakingtypes.NewCommissionRates(rates[0], rates[1], rates[2]),
+		minSelfDelegation,
+	)
+	if err != nil {
+		return err
+	}
+	_, err = tn.BroadcastMsgs(ctx, keyName, msg)
+	return err
+}
+
+// broadcastEditValidator is the gRPC counterpart of the staking edit-validator command run by StakingEditValidator.
+func (tn *ChainNode) broadcastEditValidator(ctx context.Context, keyName string, extraFlags []string) error {
+	flags, err := parseTxFlags(extraFlags, "new-moniker", "identity", "website", "security-contact", "details", "commission-rate", "min-self-delegation")
+	if err != nil {
+		return err
+	}
+	description := func(name string) string {
+		if v, ok := flags[name]; ok {
+			return v
+		}
+		return stakingtypes.DoNotModifyDesc
+	}
+
+	var rate *sdkmath.LegacyDec
+	if v, ok := flags["commission-rate"]; ok {
+		r, err := sdkmath.LegacyNewDecFromStr(v)
+		if err != nil {
+			return fmt.Errorf("invalid new commission rate %q: %w", v, err)
+		}
+		rate = &r
+	}
+	var minSelfDelegation *sdkmath.Int
+	if v, ok := flags["min-self-delegation"]; ok {
+		m, ok := sdkmath.NewIntFromString(v)
+		if !ok {
+			return fmt.Errorf("invalid minimum self delegation %q", v)
+		}
+		minSelfDelegation = &m
+	}
+
+	valoper, err := tn.KeyBech32(ctx, keyName, "val")
+	if err != nil {
+		return err
+	}
+	msg := stakingtypes.NewMsgEditValidator(valoper, stakingtypes.NewDescription(
+		description("new-moniker"), description("identity"), description("website"), description("security-contact"), description("details"),
+	), rate, minSelfDelegation)
+	_, err = tn.broadcastTx(ctx, keyName, flags, msg)
+	return err
+}
+
+// broadcastStakingMsg broadcasts the msg built from the address of keyName and amount, a coin such as "100stake".
+func (tn *ChainNode) broadcastStakingMsg(ctx context.Context, keyName, amount string, build func(delegator string, amount sdk.Coin) sdk.Msg) error {
+	coin, err := sdk.ParseCoinNormalized(amount)
+	if err != nil {
+		return err
+	}
+	return tn.broadcastMsgsFrom(ctx, keyName, func(delegator string) ([]sdk.Msg, error) {
+		return []sdk.Msg{build(delegator, coin)}, nil
+	})
+}
+
 // StakingCreateValidatorFile creates a new validator file for use in `StakingCreateValidator`.
 func (tn *ChainNode) StakingCreateValidatorFile(
 	ctx context.Context, filePath string,
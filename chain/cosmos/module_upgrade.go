@@ -5,10 +5,24 @@ import (
 	"fmt"
 
 	upgradetypes "cosmossdk.io/x/upgrade/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
 )
 
 // UpgradeSoftware executes the upgrade software command.
+// With ibc.TxModeGRPC, the flags --title, --summary, --deposit, --metadata, --expedited and --authority
+// of the governance proposal are supported in extraFlags.
 func (tn *ChainNode) UpgradeSoftware(ctx context.Context, keyName, name, info string, height int, extraFlags ...string) error {
+	if tn.UsesGRPCTx() {
+		return tn.broadcastUpgradeProposal(ctx, keyName, extraFlags, func(authority string) sdk.Msg {
+			return &upgradetypes.MsgSoftwareUpgrade{
+				Authority: authority,
+				Plan:      upgradetypes.Plan{Name: name, Height: int64(height), Info: info},
+			}
+		})
+	}
+
 	cmd := []string{"upgrade", "software-upgrade", name}
 	if height > 0 {
 		cmd = append(cmd, "--upgrade-height", fmt.Sprintf("%d", height))
@@ -26,7 +40,14 @@ func (tn *ChainNode) UpgradeSoftware(ctx context.Context, keyName, name, info st
 }
 
 // UpgradeCancel executes the upgrade cancel command.
+// With ibc.TxModeGRPC, extraFlags supports the same flags as UpgradeSoftware.
 func (tn *ChainNode) UpgradeCancel(ctx context.Context, keyName string, extraFlags ...string) error {
+	if tn.UsesGRPCTx() {
+		return tn.broadcastUpgradeProposal(ctx, keyName, extraFlags, func(authority string) sdk.Msg {
+			return &upgradetypes.MsgCancelUpgrade{Authority: authority}
+		})
+	}
+
 	cmd := []string{"upgrade", "cancel-software-upgrade"}
 
 	if len(extraFlags) > 0 {
@@ -37,6 +58,41 @@ func (tn *ChainNode) UpgradeCancel(ctx context.Context, keyName string, extraFla
 	return err
 }
 
+// broadcastUpgradeProposal is the gRPC counterpart of the upgrade commands, which submit a governance proposal
+// executing the msg built from the authority of the upgrade module, the governance module unless set by --authority.
+func (tn *ChainNode) broadcastUpgradeProposal(ctx context.Context, keyName string, extraFlags []string, build func(authority string) sdk.Msg) error {
+	flags, err := parseTxFlags(extraFlags, "title", "summary", "deposit", "metadata", "expedited", "authority", "no-validate")
+	if err != nil {
+		return err
+	}
+	expedited, err := flags.bool("expedited")
+	if err != nil {
+		return err
+	}
+	deposit, err := sdk.ParseCoinsNormalized(flags["deposit"])
+	if err != nil {
+		return err
+	}
+
+	authority := flags["authority"]
+	if authority == "" {
+		if authority, err = tn.Chain.(*CosmosChain).GetGovernanceAddress(ctx); err != nil {
+			return err
+		}
+	}
+	proposer, err := tn.KeyAddress(ctx, keyName)
+	if err != nil {
+		return err
+	}
+
+	msg, err := govv1.NewMsgSubmitProposal([]sdk.Msg{build(authority)}, deposit, proposer, flags["metadata"], flags["title"], flags["summary"], expedited)
+	if err != nil {
+		return err
+	}
+	_, err = tn.broadcastTx(ctx, keyName, flags, msg)
+	return err
+}
+
 // UpgradeQueryPlan queries the current upgrade plan.
 func (c *CosmosChain) UpgradeQueryPlan(ctx context.Context) (*upgradetypes.Plan, error) {
 	res, err := upgradetypes.NewQueryClient(c.GetNode().GrpcConn).CurrentPlan(ctx, &upgradetypes.QueryCurrentPlanRequest{})
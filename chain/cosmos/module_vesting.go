@@ -6,7 +6,9 @@ import (
 	"fmt"
 	"path"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	vestingcli "github.com/cosmos/cosmos-sdk/x/auth/vesting/client/cli"
+	vestingtypes "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
 
 	"github.com/strangelove-ventures/interchaintest/v8/dockerutil"
 )
@@ -14,6 +16,20 @@ import (
 // VestingCreateAccount creates a new vesting account funded with an allocation of tokens. The account can either be a delayed or continuous vesting account, which is determined by the '--delayed' flag.
 // All vesting accounts created will have their start time set by the committed block's time. The end_time must be provided as a UNIX epoch timestamp.
 func (tn *ChainNode) VestingCreateAccount(ctx context.Context, keyName string, toAddr string, coin string, endTime int64, flags ...string) error {
+	if tn.UsesGRPCTx() {
+		txf, err := parseTxFlags(flags, "delayed")
+		if err != nil {
+			return err
+		}
+		delayed, err := txf.bool("delayed")
+		if err != nil {
+			return err
+		}
+		return tn.broadcastVestingMsg(ctx, keyName, txf, coin, func(from string, amount sdk.Coins) sdk.Msg {
+			return &vestingtypes.MsgCreateVestingAccount{FromAddress: from, ToAddress: toAddr, Amount: amount, EndTime: endTime, Delayed: delayed}
+		})
+	}
+
 	cmd := []string{
 		"vesting", "create-vesting-account", toAddr, coin, fmt.Sprintf("%d", endTime),
 	}
@@ -28,6 +44,16 @@ func (tn *ChainNode) VestingCreateAccount(ctx context.Context, keyName string, t
 
 // VestingCreatePermanentLockedAccount creates a new vesting account funded with an allocation of tokens that are locked indefinitely.
 func (tn *ChainNode) VestingCreatePermanentLockedAccount(ctx context.Context, keyName string, toAddr string, coin string, flags ...string) error {
+	if tn.UsesGRPCTx() {
+		txf, err := parseTxFlags(flags)
+		if err != nil {
+			return err
+		}
+		return tn.broadcastVestingMsg(ctx, keyName, txf, coin, func(from string, amount sdk.Coins) sdk.Msg {
+			return &vestingtypes.MsgCreatePermanentLockedAccount{FromAddress: from, ToAddress: toAddr, Amount: amount}
+		})
+	}
+
 	cmd := []string{
 		"vesting", "create-permanent-locked-account", toAddr, coin,
 	}
@@ -44,6 +70,29 @@ func (tn *ChainNode) VestingCreatePermanentLockedAccount(ctx context.Context, ke
 // Periods are sequential, in that the duration of a period only starts at the end of the previous period.
 // The duration of the first period starts upon account creation.
 func (tn *ChainNode) VestingCreatePeriodicAccount(ctx context.Context, keyName string, toAddr string, periods vestingcli.VestingData, flags ...string) error {
+	if tn.UsesGRPCTx() {
+		txf, err := parseTxFlags(flags)
+		if err != nil {
+			return err
+		}
+		vestingPeriods := make([]vestingtypes.Period, len(periods.Periods))
+		for i, p := range periods.Periods {
+			amount, err := sdk.ParseCoinsNormalized(p.Coins)
+			if err != nil {
+				return err
+			}
+			vestingPeriods[i] = vestingtypes.Period{Length: p.Length, Amount: amount}
+		}
+		return tn.broadcastVestingMsg(ctx, keyName, txf, "", func(from string, _ sdk.Coins) sdk.Msg {
+			return &vestingtypes.MsgCreatePeriodicVestingAccount{
+				FromAddress:    from,
+				ToAddress:      toAddr,
+				StartTime:      periods.StartTime,
+				VestingPeriods: vestingPeriods,
+			}
+		})
+	}
+
 	file := "periods.json"
 	periodsJSON, err := json.MarshalIndent(periods, "", " ")
 	if err != nil {
@@ -66,3 +115,18 @@ func (tn *ChainNode) VestingCreatePeriodicAccount(ctx context.Context, keyName s
 	_, err = tn.ExecTx(ctx, keyName, cmd...)
 	return err
 }
+
+// broadcastVestingMsg broadcasts the msg built from the address of keyName and coins, e.g. "100stake,10uatom",
+// with the transaction flags of txf.
+func (tn *ChainNode) broadcastVestingMsg(ctx context.Context, keyName string, txf txFlags, coins string, build func(from string, amount sdk.Coins) sdk.Msg) error {
+	amount, err := sdk.ParseCoinsNormalized(coins)
+	if err != nil {
+		return err
+	}
+	from, err := tn.KeyAddress(ctx, keyName)
+	if err != nil {
+		return err
+	}
+	_, err = tn.broadcastTx(ctx, keyName, txf, build(from, amount))
+	return err
+}
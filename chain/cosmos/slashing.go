@@ -0,0 +1,123 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+
+	"github.com/strangelove-ventures/interchaintest/v8/testutil"
+)
+
+// SlashingState is the state of a validator after a slashing scenario.
+type SlashingState struct {
+	// Height is the height at which the validator was seen slashed.
+	Height int64
+
+	Validator   *stakingtypes.Validator
+	SigningInfo *slashingtypes.ValidatorSigningInfo
+}
+
+// ValconsAddress returns the bech32 consensus address of the node, e.g. to query its signing info.
+func (tn *ChainNode) ValconsAddress(ctx context.Context) (string, error) {
+	addr, err := tn.ConsensusAddress(ctx)
+	if err != nil {
+		return "", err
+	}
+	bz, err := hex.DecodeString(addr)
+	if err != nil {
+		return "", fmt.Errorf("malformed consensus address %s: %w", addr, err)
+	}
+	return bech32.ConvertAndEncode(tn.Chain.Config().Bech32Prefix+"valcons", bz)
+}
+
+// DoubleSign makes val double-sign with a duplicate node signing with its consensus key, see AddDuplicateValidator,
+// and waits at most maxBlocks blocks for the evidence to jail and tombstone val.
+// The duplicate is returned; it keeps running, which is harmless once val is tombstoned.
+func (c *CosmosChain) DoubleSign(ctx context.Context, val *ChainNode, maxBlocks int) (*ChainNode, SlashingState, error) {
+	dup, err := c.AddDuplicateValidator(ctx, val, false)
+	if err != nil {
+		return nil, SlashingState{}, fmt.Errorf("failed to add duplicate of %s: %w", val.Name(), err)
+	}
+
+	state, err := c.waitForSlashing(ctx, val, maxBlocks, func(s SlashingState) bool {
+		return s.Validator.Jailed && s.SigningInfo.Tombstoned
+	})
+	if err != nil {
+		return dup, state, fmt.Errorf("validator %s was not tombstoned for double-signing: %w", val.Name(), err)
+	}
+	return dup, state, nil
+}
+
+// Downtime stops the node of val until it is jailed for missing blocks, then starts it again.
+// If maxBlocks is 0, it waits at most the signed blocks window of the slashing params and a few blocks more.
+// val is not tombstoned and can unjail with SlashingUnJail once the downtime jail duration passed.
+//
+// The rest of the validators must hold more than two thirds of the voting power, for the chain to keep producing blocks.
+// val must not be the node queried by the chain, i.e. c.GetNode(), since it is down while the chain is queried.
+func (c *CosmosChain) Downtime(ctx context.Context, val *ChainNode, maxBlocks int) (SlashingState, error) {
+	if val == c.GetNode() {
+		return SlashingState{}, errors.New("cannot take down the node queried by the chain, choose another validator")
+	}
+	if maxBlocks <= 0 {
+		params, err := c.SlashingQueryParams(ctx)
+		if err != nil {
+			return SlashingState{}, err
+		}
+		maxBlocks = int(params.SignedBlocksWindow) + 10
+	}
+
+	if err := val.StopContainer(ctx); err != nil {
+		return SlashingState{}, err
+	}
+
+	state, err := c.waitForSlashing(ctx, val, maxBlocks, func(s SlashingState) bool {
+		return s.Validator.Jailed && !s.SigningInfo.Tombstoned
+	})
+	if err != nil {
+		err = fmt.Errorf("validator %s was not jailed for downtime: %w", val.Name(), err)
+	}
+
+	if startErr := val.StartContainer(ctx); startErr != nil {
+		return state, errors.Join(err, startErr)
+	}
+	return state, err
+}
+
+// waitForSlashing waits at most maxBlocks blocks until the state of val satisfies slashed.
+func (c *CosmosChain) waitForSlashing(ctx context.Context, val *ChainNode, maxBlocks int, slashed func(SlashingState) bool) (SlashingState, error) {
+	valoper, err := val.KeyBech32(ctx, valKey, "val")
+	if err != nil {
+		return SlashingState{}, err
+	}
+	valcons, err := val.ValconsAddress(ctx)
+	if err != nil {
+		return SlashingState{}, err
+	}
+
+	var state SlashingState
+	err = testutil.WaitForBlocksUtil(maxBlocks, func(int) error {
+		if err := testutil.WaitForBlocks(ctx, 1, c); err != nil {
+			return err
+		}
+		if state.Height, err = c.Height(ctx); err != nil {
+			return err
+		}
+		if state.Validator, err = c.StakingQueryValidator(ctx, valoper); err != nil {
+			return err
+		}
+		if state.SigningInfo, err = c.SlashingQuerySigningInfo(ctx, valcons); err != nil {
+			return err
+		}
+		if !slashed(state) {
+			return fmt.Errorf("jailed: %t, tombstoned: %t, missed blocks: %d",
+				state.Validator.Jailed, state.SigningInfo.Tombstoned, state.SigningInfo.MissedBlocksCounter)
+		}
+		return nil
+	})
+	return state, err
+}
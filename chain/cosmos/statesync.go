@@ -0,0 +1,107 @@
+package cosmos
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v8/testutil"
+)
+
+const (
+	// DefaultStateSyncTrustPeriod is the default trust period of the light client verifying a state sync snapshot.
+	DefaultStateSyncTrustPeriod = 168 * time.Hour
+
+	// DefaultStateSyncTimeout is the default duration to wait for a state sync node to catch up with the chain.
+	DefaultStateSyncTimeout = 5 * time.Minute
+)
+
+// StateSyncSnapshotOverrides returns the config file overrides making nodes take a state sync snapshot
+// every interval blocks and keep the keepRecent latest ones, to set as ibc.ChainConfig.ConfigFileOverrides
+// of the nodes serving snapshots.
+func StateSyncSnapshotOverrides(interval, keepRecent uint64) map[string]any {
+	stateSync := make(testutil.Toml)
+	stateSync["snapshot-interval"] = interval
+	stateSync["snapshot-keep-recent"] = keepRecent
+
+	appToml := make(testutil.Toml)
+	appToml["state-sync"] = stateSync
+
+	return map[string]any{"config/app.toml": appToml}
+}
+
+// StateSyncOptions configures a full node added with AddStateSyncFullNode.
+type StateSyncOptions struct {
+	// RPCServers are the nodes serving light blocks to verify the snapshot.
+	// Defaults to the validators. State sync requires two servers, a single node is listed twice.
+	RPCServers ChainNodes
+
+	// TrustHeight is the height of the block trusted by the light client, whose hash is queried from the chain.
+	// Defaults to the current height. It does not need to be a snapshot height.
+	TrustHeight int64
+
+	// TrustPeriod is the trust period of the light client.
+	// Defaults to DefaultStateSyncTrustPeriod.
+	TrustPeriod time.Duration
+
+	// SyncTimeout bounds the wait for the node to restore a snapshot and catch up with the chain.
+	// Defaults to DefaultStateSyncTimeout.
+	SyncTimeout time.Duration
+}
+
+// StateSyncTrust returns the hash of the block at height, to trust with the statesync config of a node.
+// If height is 0, the latest block is trusted and its height returned.
+func (c *CosmosChain) StateSyncTrust(ctx context.Context, height int64) (int64, string, error) {
+	var h *int64
+	if height > 0 {
+		h = &height
+	}
+	res, err := c.GetNode().Client.Block(ctx, h)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to query block to trust: %w", err)
+	}
+	return res.Block.Height, res.BlockID.Hash.String(), nil
+}
+
+// AddStateSyncFullNode adds a fullnode that bootstraps from a state sync snapshot of its peers instead of replaying blocks from genesis,
+// applying configFileOverrides like AddFullNodes. The node is appended to FullNodes, and is returned once it caught up with the chain.
+//
+// Some nodes of the chain must take snapshots, see StateSyncSnapshotOverrides, and one must have been taken before the node is added.
+func (c *CosmosChain) AddStateSyncFullNode(ctx context.Context, configFileOverrides map[string]any, opts StateSyncOptions) (*ChainNode, error) {
+	rpcNodes := opts.RPCServers
+	if len(rpcNodes) == 0 {
+		rpcNodes = c.Validators
+	}
+	rpcServers := make([]string, 0, max(len(rpcNodes), 2))
+	for _, n := range rpcNodes {
+		rpcServers = append(rpcServers, fmt.Sprintf("http://%s:26657", n.HostName()))
+	}
+	if len(rpcServers) == 1 {
+		rpcServers = append(rpcServers, rpcServers[0])
+	}
+	if opts.TrustPeriod <= 0 {
+		opts.TrustPeriod = DefaultStateSyncTrustPeriod
+	}
+	if opts.SyncTimeout <= 0 {
+		opts.SyncTimeout = DefaultStateSyncTimeout
+	}
+
+	trustHeight, trustHash, err := c.StateSyncTrust(ctx, opts.TrustHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.addFullNodes(ctx, configFileOverrides, 1, func(fn *ChainNode) error {
+		return fn.SetStateSyncConfig(ctx, rpcServers, trustHeight, trustHash, opts.TrustPeriod)
+	}); err != nil {
+		return nil, err
+	}
+	fn := c.FullNodes[len(c.FullNodes)-1]
+
+	syncCtx, cancel := context.WithTimeout(ctx, opts.SyncTimeout)
+	defer cancel()
+	if err := testutil.WaitForInSync(syncCtx, c, fn); err != nil {
+		return fn, fmt.Errorf("state sync node %s did not catch up with the chain: %w", fn.Name(), err)
+	}
+	return fn, nil
+}
@@ -0,0 +1,354 @@
+package cosmos
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/strangelove-ventures/interchaintest/v8/dockerutil"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+)
+
+// keyExportPassphrase encrypts the keys exported from the keyring of a node container
+// while they are imported into the in-memory keyring of the chain.
+const keyExportPassphrase = "interchaintest"
+
+// keyUser is a User identified by the uid and address of a key in the keyring of the chain.
+type keyUser struct {
+	keyName string
+	address string
+}
+
+func (u keyUser) KeyName() string          { return u.keyName }
+func (u keyUser) FormattedAddress() string { return u.address }
+
+// UsesGRPCTx reports whether the module helpers of the node broadcast transactions with BroadcastMsgs
+// instead of executing the tx subcommand of the chain binary, i.e. whether the chain is configured with ibc.TxModeGRPC.
+func (tn *ChainNode) UsesGRPCTx() bool {
+	return tn.Chain.Config().TxMode == ibc.TxModeGRPC
+}
+
+// BroadcastMsgs signs msgs with the key keyName of the node, broadcasts them over gRPC,
+// waits for the transaction to be included in a block, then returns the tx hash.
+// Unlike ExecTx, it does not run the chain binary.
+//
+// The key is read from the keyring of the chain, where CreateKey and RecoverKey create the keys with ibc.TxModeGRPC,
+// or else from the keyring of the node container the first time it is used.
+// Gas is simulated with the gas adjustment of the chain, unless the chain configures a numeric gas limit.
+func (tn *ChainNode) BroadcastMsgs(ctx context.Context, keyName string, msgs ...sdk.Msg) (string, error) {
+	return tn.BroadcastMsgsWithMemo(ctx, keyName, "", msgs...)
+}
+
+// BroadcastMsgsWithMemo is like BroadcastMsgs, with memo set on the transaction.
+func (tn *ChainNode) BroadcastMsgsWithMemo(ctx context.Context, keyName, memo string, msgs ...sdk.Msg) (string, error) {
+	res, err := tn.broadcastTx(ctx, keyName, txFlags{"note": memo}, msgs...)
+	return res.TxHash, err
+}
+
+// broadcastTx signs msgs with the key keyName of the node and broadcasts them like BroadcastMsgs,
+// configuring the transaction with the transaction flags of flags, see parseTxFlags,
+// then returns the response of the transaction included in a block.
+func (tn *ChainNode) broadcastTx(ctx context.Context, keyName string, flags txFlags, msgs ...sdk.Msg) (sdk.TxResponse, error) {
+	factoryOpt, err := tn.txFactoryOpt(flags)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+
+	uid, addr, err := tn.keyAddress(ctx, keyName)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+	address, err := sdk.Bech32ifyAddressBytes(tn.Chain.Config().Bech32Prefix, addr)
+	if err != nil {
+		return sdk.TxResponse{}, err
+	}
+
+	tn.lock.Lock()
+	defer tn.lock.Unlock()
+
+	chain := tn.Chain.(*CosmosChain)
+	chain.keyringMu.RLock()
+	defer chain.keyringMu.RUnlock()
+
+	user := keyUser{uid, address}
+	b := &Broadcaster{
+		buf:      &bytes.Buffer{},
+		keyrings: map[User]keyring.Keyring{user: chain.keyring},
+		chain:    chain,
+	}
+	b.ConfigureClientContextOptions(func(cc client.Context) client.Context {
+		// Broadcast through this node rather than the full node of the chain.
+		return cc.WithClient(tn.Client).WithGRPCClient(tn.GrpcConn)
+	})
+	b.ConfigureFactoryOptions(factoryOpt)
+
+	res, err := BroadcastTx(ctx, b, user, msgs...)
+	if err != nil {
+		return res, err
+	}
+	// The transaction can pass CheckTx, but then fail when it's actually included in a block.
+	if res.Code != 0 {
+		return res, fmt.Errorf("transaction failed with code %d: %s", res.Code, res.RawLog)
+	}
+	return res, nil
+}
+
+// broadcastMsgsFrom broadcasts the msgs built from the address of keyName with BroadcastMsgs.
+// Nothing is broadcast if build returns no msgs.
+func (tn *ChainNode) broadcastMsgsFrom(ctx context.Context, keyName string, build func(addr string) ([]sdk.Msg, error)) error {
+	addr, err := tn.KeyAddress(ctx, keyName)
+	if err != nil {
+		return err
+	}
+	msgs, err := build(addr)
+	if err != nil {
+		return err
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+	_, err = tn.BroadcastMsgs(ctx, keyName, msgs...)
+	return err
+}
+
+// txFlags are the flags of a tx subcommand passed to a helper, e.g. as extra flags,
+// parsed by parseTxFlags for the gRPC counterpart of the helper.
+type txFlags map[string]string
+
+// broadcastFlags are the transaction flags of the tx subcommands that broadcastTx applies.
+var broadcastFlags = []string{"fees", "gas", "gas-adjustment", "gas-prices", "note"}
+
+// parseTxFlags parses the flags of a tx subcommand passed to a helper, as "--name value", "--name=value",
+// or "--name" alone for a boolean flag, for the gRPC counterpart of the helper.
+// Only msgFlags, the flags of the messages built by the helper, and broadcastFlags are supported.
+func parseTxFlags(args []string, msgFlags ...string) (txFlags, error) {
+	flags := txFlags{}
+	for i := 0; i < len(args); i++ {
+		if !strings.HasPrefix(args[i], "--") {
+			return nil, fmt.Errorf("unexpected argument %q, only flags are supported with ibc.TxModeGRPC", args[i])
+		}
+		name, value, ok := strings.Cut(strings.TrimPrefix(args[i], "--"), "=")
+		if !ok {
+			value = "true"
+			if i+1 < len(args) && !strings.HasPrefix(args[i+1], "--") {
+				i++
+				value = args[i]
+			}
+		}
+		if !slices.Contains(msgFlags, name) && !slices.Contains(broadcastFlags, name) {
+			return nil, fmt.Errorf("flag --%s is not supported with ibc.TxModeGRPC", name)
+		}
+		flags[name] = value
+	}
+	return flags, nil
+}
+
+// bool returns the value of the boolean flag name, false if it is not set.
+func (f txFlags) bool(name string) (bool, error) {
+	v, ok := f[name]
+	if !ok {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, fmt.Errorf("invalid value %q of flag --%s: %w", v, name, err)
+	}
+	return b, nil
+}
+
+// txFactoryOpt returns the FactoryOpt configuring the memo, fees and gas of a transaction from the broadcastFlags of txf.
+// Unless set by txf, the gas is configured for the chain the same way TxCommand configures the --gas flag.
+func (tn *ChainNode) txFactoryOpt(txf txFlags) (FactoryOpt, error) {
+	gas := tn.Chain.Config().Gas
+	if v, ok := txf["gas"]; ok {
+		gas = v
+	}
+	gasLimit, err := strconv.ParseUint(gas, 10, 64)
+	simulate := err != nil
+	if simulate && gas != "" && gas != flags.GasFlagAuto {
+		return nil, fmt.Errorf("invalid gas %q", gas)
+	}
+
+	var gasAdjustment float64
+	if v, ok := txf["gas-adjustment"]; ok {
+		if gasAdjustment, err = strconv.ParseFloat(v, 64); err != nil {
+			return nil, fmt.Errorf("invalid gas adjustment %q: %w", v, err)
+		}
+	}
+	// The factory panics on invalid fees or gas prices.
+	if _, err := sdk.ParseCoinsNormalized(txf["fees"]); err != nil {
+		return nil, fmt.Errorf("invalid fees %q: %w", txf["fees"], err)
+	}
+	if _, err := sdk.ParseDecCoins(txf["gas-prices"]); err != nil {
+		return nil, fmt.Errorf("invalid gas prices %q: %w", txf["gas-prices"], err)
+	}
+
+	return func(f tx.Factory) tx.Factory {
+		if memo := txf["note"]; memo != "" {
+			f = f.WithMemo(memo)
+		}
+		if fees, ok := txf["fees"]; ok {
+			f = f.WithGasPrices("").WithFees(fees)
+		}
+		if gasPrices, ok := txf["gas-prices"]; ok {
+			f = f.WithGasPrices(gasPrices)
+		}
+		if gasAdjustment > 0 {
+			f = f.WithGasAdjustment(gasAdjustment)
+		}
+		if !simulate {
+			return f.WithGas(gasLimit).WithSimulateAndExecute(false)
+		}
+		if f.GasAdjustment() <= 0 {
+			f = f.WithGasAdjustment(flags.DefaultGasAdjustment)
+		}
+		return f.WithSimulateAndExecute(true)
+	}, nil
+}
+
+// KeyAddress returns the bech32 account address of the key keyName of the node, like AccountKeyBech32.
+func (tn *ChainNode) KeyAddress(ctx context.Context, keyName string) (string, error) {
+	return tn.AccountKeyBech32(ctx, keyName)
+}
+
+// keyUID returns the uid in the keyring of the chain of the key keyName created on the node, e.g. its validator key.
+// The keys created with CosmosChain.CreateKey and CosmosChain.RecoverKey are shared by all the nodes of the chain,
+// and their uid is their name.
+func (tn *ChainNode) keyUID(keyName string) string {
+	return tn.Name() + "/" + keyName
+}
+
+// keyAddress returns the uid and the account address of the key keyName of the node in the keyring of the chain.
+// A key created on the node shadows a key of the same name shared by the chain.
+// A key found in neither, e.g. created by the chain binary, is imported from the keyring of the node container.
+func (tn *ChainNode) keyAddress(ctx context.Context, keyName string) (string, sdk.AccAddress, error) {
+	c := tn.Chain.(*CosmosChain)
+
+	c.keyringMu.RLock()
+	for _, uid := range []string{tn.keyUID(keyName), keyName} {
+		if record, err := c.keyring.Key(uid); err == nil {
+			c.keyringMu.RUnlock()
+			addr, err := record.GetAddress()
+			return uid, addr, err
+		}
+	}
+	c.keyringMu.RUnlock()
+
+	uid := tn.keyUID(keyName)
+	if err := tn.importContainerKey(ctx, uid, keyName); err != nil {
+		return "", nil, err
+	}
+
+	c.keyringMu.RLock()
+	defer c.keyringMu.RUnlock()
+
+	record, err := c.keyring.Key(uid)
+	if err != nil {
+		return "", nil, err
+	}
+	addr, err := record.GetAddress()
+	return uid, addr, err
+}
+
+// createKey creates the key uid in the keyring of the chain, recovered from mnemonic or from a new mnemonic if empty,
+// then writes it as keyName to the keyring of the node container, so that the chain binary can use it too.
+// Like the keys created by the chain binary, it is a secp256k1 key derived with the coin type of the chain.
+func (tn *ChainNode) createKey(ctx context.Context, uid, keyName, mnemonic string) error {
+	c := tn.Chain.(*CosmosChain)
+	coinType, err := strconv.ParseUint(c.cfg.CoinType, 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid coin type: %w", err)
+	}
+	hdPath := hd.CreateHDPath(uint32(coinType), 0, 0).String()
+
+	c.keyringMu.Lock()
+	_ = c.keyring.Delete(uid)
+	if mnemonic == "" {
+		_, mnemonic, err = c.keyring.NewMnemonic(uid, keyring.English, hdPath, "", hd.Secp256k1)
+	} else {
+		_, err = c.keyring.NewAccount(uid, mnemonic, "", hdPath, hd.Secp256k1)
+	}
+	c.keyringMu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to create key %q: %w", keyName, err)
+	}
+
+	localDir, err := os.MkdirTemp("", "interchaintest-keyring-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(localDir)
+
+	kr, err := keyring.New("", keyring.BackendTest, localDir, nil, c.cdc)
+	if err != nil {
+		return err
+	}
+	if _, err := kr.NewAccount(keyName, mnemonic, "", hdPath, hd.Secp256k1); err != nil {
+		return fmt.Errorf("failed to create key %q: %w", keyName, err)
+	}
+
+	files, err := os.ReadDir(filepath.Join(localDir, "keyring-test"))
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		content, err := os.ReadFile(filepath.Join(localDir, "keyring-test", f.Name()))
+		if err != nil {
+			return err
+		}
+		if err := tn.WriteFile(ctx, content, path.Join("keyring-test", f.Name())); err != nil {
+			return fmt.Errorf("failed to write key %q to keyring of %s: %w", keyName, tn.Name(), err)
+		}
+	}
+	return nil
+}
+
+// importContainerKey imports the key keyName from the keyring of the node container as uid into the keyring of the chain.
+func (tn *ChainNode) importContainerKey(ctx context.Context, uid, keyName string) error {
+	localDir, err := os.MkdirTemp("", "interchaintest-keyring-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(localDir)
+
+	containerKeyringDir := path.Join(tn.HomeDir(), "keyring-test")
+	kr, err := dockerutil.NewLocalKeyringFromDockerContainer(ctx, tn.DockerClient, localDir, containerKeyringDir, tn.containerLifecycle.ContainerID())
+	if err != nil {
+		return fmt.Errorf("failed to read keyring of %s: %w", tn.Name(), err)
+	}
+
+	armor, err := kr.ExportPrivKeyArmor(keyName, keyExportPassphrase)
+	if err != nil {
+		return fmt.Errorf("failed to export key %q from keyring of %s: %w", keyName, tn.Name(), err)
+	}
+
+	c := tn.Chain.(*CosmosChain)
+	c.keyringMu.Lock()
+	defer c.keyringMu.Unlock()
+
+	_ = c.keyring.Delete(uid)
+	return c.keyring.ImportPrivKey(uid, armor, keyExportPassphrase)
+}
+
+// forgetKey removes the key keyName created on the node from the keyring of the chain,
+// so that it is imported again from the keyring of the node container the next time it is used.
+func (tn *ChainNode) forgetKey(keyName string) {
+	c := tn.Chain.(*CosmosChain)
+	c.keyringMu.Lock()
+	defer c.keyringMu.Unlock()
+
+	_ = c.keyring.Delete(tn.keyUID(keyName))
+}
@@ -0,0 +1,77 @@
+package cosmos
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+)
+
+func TestKeyBech32GRPC(t *testing.T) {
+	ctx := context.Background()
+	c := NewCosmosChain(t.Name(), ibc.ChainConfig{
+		ChainID:      "test-1",
+		Bech32Prefix: "cosmos",
+		CoinType:     "118",
+		TxMode:       ibc.TxModeGRPC,
+	}, 2, 0, zap.NewNop())
+	val0 := NewChainNode(zap.NewNop(), true, c, nil, "", t.Name(), ibc.DockerImage{}, 0)
+	val1 := NewChainNode(zap.NewNop(), true, c, nil, "", t.Name(), ibc.DockerImage{}, 1)
+
+	newKey := func(uid string) sdk.AccAddress {
+		record, _, err := c.keyring.NewMnemonic(uid, keyring.English, hd.CreateHDPath(118, 0, 0).String(), "", hd.Secp256k1)
+		require.NoError(t, err)
+		addr, err := record.GetAddress()
+		require.NoError(t, err)
+		return addr
+	}
+	// A key of the chain is shared by its nodes, unless a node has its own key of the same name.
+	user := newKey("user")
+	val0Key := newKey(val0.keyUID("user"))
+
+	addr, err := val0.KeyBech32(ctx, "user", "")
+	require.NoError(t, err)
+	require.Equal(t, sdk.MustBech32ifyAddressBytes("cosmos", val0Key), addr)
+
+	addr, err = val1.AccountKeyBech32(ctx, "user")
+	require.NoError(t, err)
+	require.Equal(t, sdk.MustBech32ifyAddressBytes("cosmos", user), addr)
+
+	addr, err = val1.KeyBech32(ctx, "user", "val")
+	require.NoError(t, err)
+	require.Equal(t, sdk.MustBech32ifyAddressBytes("cosmosvaloper", user), addr)
+
+	addr, err = val1.KeyBech32(ctx, "user", "cons")
+	require.NoError(t, err)
+	require.Equal(t, sdk.MustBech32ifyAddressBytes("cosmosvalcons", user), addr)
+
+	_, err = val1.KeyBech32(ctx, "user", "nope")
+	require.ErrorContains(t, err, `invalid bech32 prefix "nope"`)
+}
+
+func TestParseTxFlags(t *testing.T) {
+	txf, err := parseTxFlags([]string{"--label", "my-contract", "--no-admin", "--fees=10uatom", "--gas", "auto"}, "label", "no-admin")
+	require.NoError(t, err)
+	require.Equal(t, txFlags{"label": "my-contract", "no-admin": "true", "fees": "10uatom", "gas": "auto"}, txf)
+
+	noAdmin, err := txf.bool("no-admin")
+	require.NoError(t, err)
+	require.True(t, noAdmin)
+
+	delayed, err := txf.bool("delayed")
+	require.NoError(t, err)
+	require.False(t, delayed)
+
+	_, err = parseTxFlags([]string{"--keyring-dir", "/tmp"})
+	require.ErrorContains(t, err, "flag --keyring-dir is not supported with ibc.TxModeGRPC")
+
+	_, err = parseTxFlags([]string{"positional"})
+	require.ErrorContains(t, err, `unexpected argument "positional"`)
+}
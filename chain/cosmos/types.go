@@ -25,6 +25,16 @@ type TxProposalv1 struct {
 	Expedited bool   `json:"expedited,omitempty"`
 }
 
+// GroupProposal contains the details of an x/group proposal, in the format of the group submit-proposal command.
+type GroupProposal struct {
+	GroupPolicyAddress string            `json:"group_policy_address"`
+	Messages           []json.RawMessage `json:"messages,omitempty"`
+	Metadata           string            `json:"metadata"`
+	Proposers          []string          `json:"proposers"`
+	Title              string            `json:"title"`
+	Summary            string            `json:"summary"`
+}
+
 // ProtoMessage is implemented by generated protocol buffer messages.
 // Pulled from github.com/cosmos/gogoproto/proto.
 type ProtoMessage interface {
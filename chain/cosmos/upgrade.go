@@ -0,0 +1,387 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	govv1beta1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1beta1"
+
+	"github.com/strangelove-ventures/interchaintest/v8/testutil"
+)
+
+// UpgradeMethod is how Upgrade halts a chain before swapping the version of its nodes.
+type UpgradeMethod int
+
+const (
+	// UpgradeGov halts the chain with a software upgrade proposal, voted yes by all validators.
+	// The upgrade handler of the new version runs at the upgrade height.
+	UpgradeGov UpgradeMethod = iota
+
+	// UpgradeHaltHeight halts the chain by restarting its nodes with the halt-height of app.toml.
+	// No upgrade handler runs, so it only suits versions that do not break the state machine.
+	UpgradeHaltHeight
+)
+
+func (m UpgradeMethod) String() string {
+	switch m {
+	case UpgradeGov:
+		return "gov"
+	case UpgradeHaltHeight:
+		return "halt-height"
+	default:
+		return "unknown"
+	}
+}
+
+const (
+	// DefaultUpgradeHeightDelta is the default number of blocks between the start of an upgrade and the upgrade height.
+	// The voting period of upgrade proposals must end within those blocks.
+	DefaultUpgradeHeightDelta = 20
+
+	// DefaultUpgradeStallTimeout is the default duration without a new block after which a chain is considered halted.
+	DefaultUpgradeStallTimeout = 10 * time.Second
+
+	// DefaultUpgradeHaltTimeout is the default duration to wait for a chain to halt at the upgrade height.
+	DefaultUpgradeHaltTimeout = 5 * time.Minute
+
+	// DefaultUpgradeResumeTimeout is the default duration to wait for an upgraded chain to produce blocks.
+	DefaultUpgradeResumeTimeout = 2 * time.Minute
+
+	// DefaultBlocksAfterUpgrade is the default number of blocks an upgraded chain must produce.
+	DefaultBlocksAfterUpgrade = 2
+)
+
+// UpgradeStep is a software upgrade performed by Upgrade.
+type UpgradeStep struct {
+	// Name is the name of the upgrade plan, i.e. of the upgrade handler of the new version.
+	// It is required by UpgradeGov.
+	Name string
+
+	// Repository and Version are the docker image of the new version.
+	// Repository defaults to the repository of the current image of the chain.
+	Repository string
+	Version    string
+
+	// Info is the optional info of the upgrade plan.
+	Info string
+}
+
+// UpgradeOptions configures Upgrade. The zero value upgrades with UpgradeGov and default timeouts.
+type UpgradeOptions struct {
+	Method UpgradeMethod
+
+	// HeightDelta is the number of blocks between the start of each step and its upgrade height.
+	// Defaults to DefaultUpgradeHeightDelta.
+	HeightDelta int64
+
+	// Deposit is the deposit of upgrade proposals, e.g. "10000000uatom".
+	// Defaults to the minimum deposit of the gov params, which requires SDK v0.46 or later.
+	Deposit string
+
+	// StallTimeout is the duration without a new block at the upgrade height after which the chain is considered halted.
+	// With UpgradeGov, the chain is also considered halted once a validator logs that the upgrade is needed.
+	// Defaults to DefaultUpgradeStallTimeout.
+	StallTimeout time.Duration
+
+	// HaltTimeout bounds the wait for the chain to halt, including the voting period of UpgradeGov.
+	// Defaults to DefaultUpgradeHaltTimeout.
+	HaltTimeout time.Duration
+
+	// BlocksAfterUpgrade is the number of blocks the upgraded chain must produce for a step to succeed.
+	// Defaults to DefaultBlocksAfterUpgrade.
+	BlocksAfterUpgrade int
+
+	// ResumeTimeout bounds the wait for BlocksAfterUpgrade blocks.
+	// Defaults to DefaultUpgradeResumeTimeout.
+	ResumeTimeout time.Duration
+}
+
+func (o UpgradeOptions) withDefaults() UpgradeOptions {
+	if o.HeightDelta <= 0 {
+		o.HeightDelta = DefaultUpgradeHeightDelta
+	}
+	if o.StallTimeout <= 0 {
+		o.StallTimeout = DefaultUpgradeStallTimeout
+	}
+	if o.HaltTimeout <= 0 {
+		o.HaltTimeout = DefaultUpgradeHaltTimeout
+	}
+	if o.BlocksAfterUpgrade <= 0 {
+		o.BlocksAfterUpgrade = DefaultBlocksAfterUpgrade
+	}
+	if o.ResumeTimeout <= 0 {
+		o.ResumeTimeout = DefaultUpgradeResumeTimeout
+	}
+	return o
+}
+
+// UpgradePhase is a phase of an upgrade step.
+type UpgradePhase string
+
+const (
+	UpgradePhasePropose UpgradePhase = "propose"
+	UpgradePhaseHalt    UpgradePhase = "halt"
+	UpgradePhaseRestart UpgradePhase = "restart"
+	UpgradePhaseResume  UpgradePhase = "resume"
+)
+
+// UpgradeResult is the outcome of an upgrade step.
+type UpgradeResult struct {
+	Step UpgradeStep
+
+	// UpgradeHeight is the height of the upgrade plan, or the halt-height of the nodes.
+	UpgradeHeight int64
+
+	// HaltedHeight is the last height of the chain before the upgrade.
+	HaltedHeight int64
+
+	// ProposalID is the ID of the upgrade proposal of UpgradeGov.
+	ProposalID uint64
+
+	// Propose is the duration of submitting and voting the upgrade proposal, or of restarting the nodes with a halt-height.
+	Propose time.Duration
+	// Halt is the duration from the end of Propose to the halt of the chain.
+	Halt time.Duration
+	// Restart is the duration of stopping the nodes, swapping their version and starting them again.
+	Restart time.Duration
+	// Resume is the duration from the end of Restart to BlocksAfterUpgrade new blocks.
+	Resume time.Duration
+	// Total is the duration of the whole step.
+	Total time.Duration
+}
+
+// UpgradeError is the error of the phase of an upgrade step that failed.
+type UpgradeError struct {
+	Step  UpgradeStep
+	Phase UpgradePhase
+	Err   error
+}
+
+func (e *UpgradeError) Error() string {
+	if e.Step.Name == "" {
+		return fmt.Sprintf("upgrade to version %s: %s: %v", e.Step.Version, e.Phase, e.Err)
+	}
+	return fmt.Sprintf("upgrade %q to version %s: %s: %v", e.Step.Name, e.Step.Version, e.Phase, e.Err)
+}
+
+func (e *UpgradeError) Unwrap() error {
+	return e.Err
+}
+
+// Upgrade upgrades the chain in place to the version of each step in order,
+// as with cosmovisor upgrading through successive versions.
+// Each step halts the chain at an upgrade height with the method of opts,
+// stops all nodes, swaps their docker image, starts them again, and waits for new blocks.
+//
+// The results of the steps that were started are returned, with timings of each phase.
+// If a step fails, the error is an *UpgradeError and the following steps are not started.
+func (c *CosmosChain) Upgrade(ctx context.Context, opts UpgradeOptions, steps ...UpgradeStep) ([]UpgradeResult, error) {
+	opts = opts.withDefaults()
+
+	results := make([]UpgradeResult, 0, len(steps))
+	for _, step := range steps {
+		res, err := c.upgradeStep(ctx, opts, step)
+		results = append(results, res)
+		if err != nil {
+			return results, err
+		}
+	}
+	return results, nil
+}
+
+func (c *CosmosChain) upgradeStep(ctx context.Context, opts UpgradeOptions, step UpgradeStep) (res UpgradeResult, err error) {
+	res.Step = step
+	start := time.Now()
+	defer func() {
+		res.Total = time.Since(start)
+	}()
+	fail := func(phase UpgradePhase, err error) (UpgradeResult, error) {
+		return res, &UpgradeError{Step: step, Phase: phase, Err: err}
+	}
+
+	if step.Version == "" {
+		return fail(UpgradePhasePropose, errors.New("no version to upgrade to"))
+	}
+	if step.Repository == "" {
+		step.Repository = c.Config().Images[0].Repository
+		res.Step = step
+	}
+
+	height, err := c.Height(ctx)
+	if err != nil {
+		return fail(UpgradePhasePropose, err)
+	}
+	res.UpgradeHeight = height + opts.HeightDelta
+
+	planName := ""
+	switch opts.Method {
+	case UpgradeGov:
+		if step.Name == "" {
+			return fail(UpgradePhasePropose, errors.New("upgrade name required for a software upgrade proposal"))
+		}
+		planName = step.Name
+		if res.ProposalID, err = c.proposeUpgrade(ctx, opts, step, height, res.UpgradeHeight); err != nil {
+			return fail(UpgradePhasePropose, err)
+		}
+	case UpgradeHaltHeight:
+		if err := c.restartWithHaltHeight(ctx, res.UpgradeHeight); err != nil {
+			return fail(UpgradePhasePropose, err)
+		}
+	default:
+		return fail(UpgradePhasePropose, fmt.Errorf("unknown upgrade method %d", opts.Method))
+	}
+	res.Propose = time.Since(start)
+
+	haltCtx, cancel := context.WithTimeout(ctx, opts.HaltTimeout)
+	defer cancel()
+	res.HaltedHeight, err = c.waitForHalt(haltCtx, res.UpgradeHeight, planName, opts.StallTimeout)
+	res.Halt = time.Since(start) - res.Propose
+	if err != nil {
+		return fail(UpgradePhaseHalt, err)
+	}
+
+	restartStart := time.Now()
+	if err := c.StopAllNodes(ctx); err != nil {
+		return fail(UpgradePhaseRestart, err)
+	}
+	if opts.Method == UpgradeHaltHeight {
+		if err := c.setHaltHeight(ctx, 0); err != nil {
+			return fail(UpgradePhaseRestart, err)
+		}
+	}
+	c.UpgradeVersion(ctx, c.GetNode().DockerClient, step.Repository, step.Version)
+	if err := c.StartAllNodes(ctx); err != nil {
+		return fail(UpgradePhaseRestart, err)
+	}
+	res.Restart = time.Since(restartStart)
+
+	resumeStart := time.Now()
+	resumeCtx, cancel := context.WithTimeout(ctx, opts.ResumeTimeout)
+	defer cancel()
+	err = testutil.WaitForBlocks(resumeCtx, opts.BlocksAfterUpgrade, c)
+	res.Resume = time.Since(resumeStart)
+	if err != nil {
+		return fail(UpgradePhaseResume, fmt.Errorf("chain did not produce blocks after upgrade: %w", err))
+	}
+
+	return res, nil
+}
+
+// proposeUpgrade submits a software upgrade proposal from the first validator, has all validators vote yes,
+// and waits for the proposal to pass before upgradeHeight.
+func (c *CosmosChain) proposeUpgrade(ctx context.Context, opts UpgradeOptions, step UpgradeStep, height, upgradeHeight int64) (uint64, error) {
+	deposit := opts.Deposit
+	if deposit == "" {
+		params, err := c.GovQueryParams(ctx, "deposit")
+		if err != nil {
+			return 0, fmt.Errorf("failed to query min deposit, set UpgradeOptions.Deposit: %w", err)
+		}
+		deposit = sdk.Coins(params.MinDeposit).String()
+	}
+
+	txHash, err := c.Validators[0].UpgradeProposal(ctx, valKey, SoftwareUpgradeProposal{
+		Deposit:     deposit,
+		Title:       "Upgrade " + step.Name,
+		Name:        step.Name,
+		Description: fmt.Sprintf("Upgrade to %s at height %d", step.Version, upgradeHeight),
+		Height:      upgradeHeight,
+		Info:        step.Info,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to submit upgrade proposal: %w", err)
+	}
+	tx, err := c.txProposal(txHash)
+	if err != nil {
+		return 0, err
+	}
+	proposalID, err := strconv.ParseUint(tx.ProposalID, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid proposal ID %q: %w", tx.ProposalID, err)
+	}
+
+	if err := c.VoteOnProposalAllValidators(ctx, proposalID, ProposalVoteYes); err != nil {
+		return proposalID, err
+	}
+	if _, err := PollForProposalStatus(ctx, c, height, upgradeHeight-1, proposalID, govv1beta1.StatusPassed); err != nil {
+		return proposalID, fmt.Errorf("proposal %d did not pass before upgrade height %d: %w", proposalID, upgradeHeight, err)
+	}
+	return proposalID, nil
+}
+
+// restartWithHaltHeight restarts all nodes with the halt-height haltHeight.
+func (c *CosmosChain) restartWithHaltHeight(ctx context.Context, haltHeight int64) error {
+	if err := c.StopAllNodes(ctx); err != nil {
+		return err
+	}
+	if err := c.setHaltHeight(ctx, haltHeight); err != nil {
+		return err
+	}
+	return c.StartAllNodes(ctx)
+}
+
+// setHaltHeight sets the halt-height of all nodes, which takes effect when they are started.
+func (c *CosmosChain) setHaltHeight(ctx context.Context, haltHeight int64) error {
+	for _, n := range c.Nodes() {
+		if err := n.SetHaltHeight(ctx, haltHeight); err != nil {
+			return fmt.Errorf("failed to set halt-height of %s: %w", n.Name(), err)
+		}
+	}
+	return nil
+}
+
+// waitForHalt waits until the chain stops producing blocks at upgradeHeight, and returns its last height.
+// The chain is halted once its height stalled for stall at upgradeHeight or the height before,
+// as SDK versions differ on whether the block at the upgrade height is committed,
+// or once a validator logs that the upgrade planName is needed.
+func (c *CosmosChain) waitForHalt(ctx context.Context, upgradeHeight int64, planName string, stall time.Duration) (int64, error) {
+	var (
+		last      int64
+		lastBlock = time.Now()
+	)
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return last, fmt.Errorf("chain did not halt at upgrade height %d, last height %d: %w", upgradeHeight, last, ctx.Err())
+		case <-ticker.C:
+		}
+
+		// The height cannot be queried once the node stopped.
+		if h, err := c.Height(ctx); err == nil && h != last {
+			last, lastBlock = h, time.Now()
+		}
+
+		if last > upgradeHeight {
+			return last, fmt.Errorf("chain did not halt at upgrade height %d, it is at height %d", upgradeHeight, last)
+		}
+		if last < upgradeHeight-1 {
+			continue
+		}
+		if time.Since(lastBlock) >= stall {
+			return last, nil
+		}
+		if planName != "" && c.upgradeNeeded(ctx, planName) {
+			return last, nil
+		}
+	}
+}
+
+// upgradeNeeded reports whether a validator logged that the upgrade planName is needed,
+// which is how x/upgrade halts a chain at the height of an upgrade plan.
+func (c *CosmosChain) upgradeNeeded(ctx context.Context, planName string) bool {
+	needed := fmt.Sprintf("UPGRADE %q NEEDED", planName)
+	for _, v := range c.Validators {
+		logs, err := v.Logs(ctx, 100)
+		if err == nil && strings.Contains(logs, needed) {
+			return true
+		}
+	}
+	return false
+}
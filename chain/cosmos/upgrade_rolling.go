@@ -0,0 +1,275 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/strangelove-ventures/interchaintest/v8/testutil"
+)
+
+// DefaultRollingSyncTimeout is the default duration to wait for a restarted node to catch up with the chain.
+const DefaultRollingSyncTimeout = 2 * time.Minute
+
+// RollingUpgradeOptions configures RollingUpgrade. The zero value upgrades all nodes with default timeouts.
+type RollingUpgradeOptions struct {
+	// Nodes are the nodes to upgrade, one at a time, in order.
+	// Defaults to all nodes of the chain, validators first.
+	// Upgrading a subset of the nodes leaves the chain running mixed versions.
+	Nodes ChainNodes
+
+	// SyncTimeout bounds the wait for each restarted node to catch up with the rest of the chain.
+	// Defaults to DefaultRollingSyncTimeout.
+	SyncTimeout time.Duration
+
+	// StallTimeout is the longest the chain may go without a new block while a node restarts
+	// before liveness is considered lost.
+	// Defaults to DefaultUpgradeStallTimeout.
+	StallTimeout time.Duration
+
+	// BlocksBetween is the number of blocks the chain must produce after a node caught up,
+	// before the next node is restarted.
+	BlocksBetween int
+}
+
+// RollingUpgradeNodeResult is the outcome of upgrading one node with RollingUpgrade.
+type RollingUpgradeNodeResult struct {
+	Node *ChainNode
+
+	// PreviousImage is the image of the node before the upgrade.
+	PreviousImage ibc.DockerImage
+
+	// Restart is the duration of stopping the node, swapping its image and starting it again.
+	Restart time.Duration
+	// CatchUp is the duration from the end of Restart until the node is in sync with the chain.
+	CatchUp time.Duration
+
+	// MaxBlockInterval is the longest the rest of the chain went without a new block while the node restarted and caught up.
+	MaxBlockInterval time.Duration
+
+	// LivenessLost reports whether MaxBlockInterval reached the stall timeout,
+	// e.g. because the node holds more than a third of the voting power.
+	// A chain of a single node always loses liveness.
+	LivenessLost bool
+}
+
+// RollingUpgradeResult is the outcome of RollingUpgrade.
+type RollingUpgradeResult struct {
+	// Nodes are the results of the nodes that were restarted, in order.
+	Nodes []RollingUpgradeNodeResult
+
+	// LivenessLost reports whether the chain lost liveness while any node restarted.
+	LivenessLost bool
+
+	// Total is the duration of the whole upgrade.
+	Total time.Duration
+}
+
+// RollingUpgrade upgrades nodes of the chain to image one at a time, while the rest of the chain keeps producing blocks,
+// as validators roll out a release that does not break consensus.
+// Each node is restarted with image and must catch up with the chain before the next node is restarted.
+//
+// Liveness of the chain is watched during each restart and reported in the result, not as an error.
+// If a node fails to restart or to catch up, the error is an *UpgradeError and the following nodes are not restarted.
+func (c *CosmosChain) RollingUpgrade(ctx context.Context, image ibc.DockerImage, opts RollingUpgradeOptions) (RollingUpgradeResult, error) {
+	var res RollingUpgradeResult
+	start := time.Now()
+	defer func() {
+		res.Total = time.Since(start)
+	}()
+
+	nodes := opts.Nodes
+	if len(nodes) == 0 {
+		nodes = c.Nodes()
+	}
+	if opts.SyncTimeout <= 0 {
+		opts.SyncTimeout = DefaultRollingSyncTimeout
+	}
+	if opts.StallTimeout <= 0 {
+		opts.StallTimeout = DefaultUpgradeStallTimeout
+	}
+	step := UpgradeStep{Repository: image.Repository, Version: image.Version}
+	if image.Repository == "" || image.Version == "" {
+		return res, &UpgradeError{Step: step, Phase: UpgradePhaseRestart, Err: errors.New("image repository and version required")}
+	}
+	if len(nodes) == 0 {
+		return res, &UpgradeError{Step: step, Phase: UpgradePhaseRestart, Err: errors.New("no nodes to upgrade")}
+	}
+
+	c.pullImage(ctx, nodes[0].DockerClient, image)
+
+	for i, n := range nodes {
+		nodeRes, err := c.rollNode(ctx, n, image, opts)
+		res.Nodes = append(res.Nodes, nodeRes)
+		res.LivenessLost = res.LivenessLost || nodeRes.LivenessLost
+		if err != nil {
+			return res, err
+		}
+
+		if opts.BlocksBetween > 0 && i < len(nodes)-1 {
+			waitCtx, cancel := context.WithTimeout(ctx, opts.SyncTimeout)
+			err := testutil.WaitForBlocks(waitCtx, opts.BlocksBetween, c.Nodes())
+			cancel()
+			if err != nil {
+				return res, &UpgradeError{Step: step, Phase: UpgradePhaseResume, Err: fmt.Errorf("chain did not produce blocks after upgrading %s: %w", n.Name(), err)}
+			}
+		}
+	}
+
+	// Once every node runs the new image, it is the image of the chain.
+	for _, n := range c.Nodes() {
+		if n.Image.Repository != image.Repository || n.Image.Version != image.Version {
+			return res, nil
+		}
+	}
+	c.cfg.Images[0].Repository = image.Repository
+	c.cfg.Images[0].Version = image.Version
+	return res, nil
+}
+
+// rollNode restarts n with image and waits until it caught up with the rest of the chain,
+// watching the liveness of the chain meanwhile.
+func (c *CosmosChain) rollNode(ctx context.Context, n *ChainNode, image ibc.DockerImage, opts RollingUpgradeOptions) (RollingUpgradeNodeResult, error) {
+	res := RollingUpgradeNodeResult{Node: n, PreviousImage: n.Image}
+	fail := func(phase UpgradePhase, err error) (RollingUpgradeNodeResult, error) {
+		step := UpgradeStep{Repository: image.Repository, Version: image.Version}
+		return res, &UpgradeError{Step: step, Phase: phase, Err: fmt.Errorf("%s: %w", n.Name(), err)}
+	}
+
+	var others ChainNodes
+	for _, o := range c.Nodes() {
+		if o != n {
+			others = append(others, o)
+		}
+	}
+
+	var stopWatch func() time.Duration
+	if len(others) > 0 {
+		stopWatch = watchBlockInterval(ctx, others, 500*time.Millisecond)
+	}
+	defer func() {
+		if stopWatch != nil {
+			res.MaxBlockInterval = stopWatch()
+			res.LivenessLost = res.MaxBlockInterval >= opts.StallTimeout
+		} else {
+			// The chain cannot produce blocks while its only node restarts.
+			res.MaxBlockInterval = res.Restart + res.CatchUp
+			res.LivenessLost = true
+		}
+	}()
+
+	restartStart := time.Now()
+	if err := c.restartNodeWithImage(ctx, n, image); err != nil {
+		res.Restart = time.Since(restartStart)
+		return fail(UpgradePhaseRestart, err)
+	}
+	res.Restart = time.Since(restartStart)
+
+	catchUpStart := time.Now()
+	syncCtx, cancel := context.WithTimeout(ctx, opts.SyncTimeout)
+	defer cancel()
+	var err error
+	if len(others) > 0 {
+		err = testutil.WaitForInSync(syncCtx, others, n)
+	} else {
+		err = testutil.WaitForBlocks(syncCtx, 1, n)
+	}
+	res.CatchUp = time.Since(catchUpStart)
+	if err != nil {
+		return fail(UpgradePhaseResume, fmt.Errorf("node did not catch up with the chain: %w", err))
+	}
+	return res, nil
+}
+
+// restartNodeWithImage stops and removes the container of n, and starts a new container of image.
+func (c *CosmosChain) restartNodeWithImage(ctx context.Context, n *ChainNode, image ibc.DockerImage) error {
+	if err := n.StopContainer(ctx); err != nil {
+		return err
+	}
+	if err := n.RemoveContainer(ctx); err != nil {
+		return err
+	}
+
+	// prevent client calls during this time
+	c.findTxMu.Lock()
+	defer c.findTxMu.Unlock()
+
+	n.Image.Repository = image.Repository
+	n.Image.Version = image.Version
+	if err := n.CreateNodeContainer(ctx); err != nil {
+		return err
+	}
+	return n.StartContainer(ctx)
+}
+
+// Height returns the highest height of the nodes that respond,
+// so that the nodes are a testutil.ChainHeighter of the chain while some of them are down.
+func (nodes ChainNodes) Height(ctx context.Context) (int64, error) {
+	var (
+		height int64
+		errs   []error
+	)
+	for _, n := range nodes {
+		h, err := n.Height(ctx)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		height = max(height, h)
+	}
+	if height == 0 {
+		return 0, fmt.Errorf("no node responded: %w", errors.Join(errs...))
+	}
+	return height, nil
+}
+
+// watchBlockInterval polls the height of chain until the returned function is called,
+// which returns the longest interval without a new block.
+func watchBlockInterval(ctx context.Context, chain testutil.ChainHeighter, poll time.Duration) func() time.Duration {
+	ctx, cancel := context.WithCancel(ctx)
+
+	var (
+		mu          sync.Mutex
+		maxInterval time.Duration
+		lastBlock   = time.Now()
+		last        int64
+		wg          sync.WaitGroup
+	)
+	observe := func(now time.Time) {
+		mu.Lock()
+		defer mu.Unlock()
+		maxInterval = max(maxInterval, now.Sub(lastBlock))
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(poll)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			h, err := chain.Height(ctx)
+			now := time.Now()
+			observe(now)
+			if err == nil && h > last {
+				mu.Lock()
+				last, lastBlock = h, now
+				mu.Unlock()
+			}
+		}
+	}()
+
+	return func() time.Duration {
+		cancel()
+		wg.Wait()
+		observe(time.Now())
+		return maxInterval
+	}
+}
@@ -0,0 +1,54 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+)
+
+type fakeHeighter struct {
+	height atomic.Int64
+	down   atomic.Bool
+}
+
+func (f *fakeHeighter) Height(context.Context) (int64, error) {
+	if f.down.Load() {
+		return 0, errors.New("down")
+	}
+	return f.height.Load(), nil
+}
+
+func TestWatchBlockInterval(t *testing.T) {
+	ctx := context.Background()
+	chain := &fakeHeighter{}
+
+	stop := watchBlockInterval(ctx, chain, time.Millisecond)
+	for i := 0; i < 20; i++ {
+		chain.height.Add(1)
+		time.Sleep(2 * time.Millisecond)
+	}
+	require.Less(t, stop(), 100*time.Millisecond)
+
+	stop = watchBlockInterval(ctx, chain, time.Millisecond)
+	chain.down.Store(true)
+	time.Sleep(200 * time.Millisecond)
+	chain.down.Store(false)
+	require.GreaterOrEqual(t, stop(), 150*time.Millisecond, "no new block while the chain is down")
+}
+
+func TestRollingUpgradeNoNodes(t *testing.T) {
+	c := NewCosmosChain(t.Name(), ibc.ChainConfig{ChainID: "test-1"}, 0, 0, zap.NewNop())
+
+	_, err := c.RollingUpgrade(context.Background(), ibc.DockerImage{Repository: "repo", Version: "v2"}, RollingUpgradeOptions{})
+	var upgradeErr *UpgradeError
+	require.ErrorAs(t, err, &upgradeErr)
+	require.Equal(t, UpgradePhaseRestart, upgradeErr.Phase)
+	require.ErrorContains(t, err, "no nodes to upgrade")
+}
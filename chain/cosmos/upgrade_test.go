@@ -0,0 +1,39 @@
+package cosmos
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpgradeOptionsDefaults(t *testing.T) {
+	opts := UpgradeOptions{}.withDefaults()
+	require.Equal(t, UpgradeGov, opts.Method)
+	require.EqualValues(t, DefaultUpgradeHeightDelta, opts.HeightDelta)
+	require.Equal(t, DefaultUpgradeStallTimeout, opts.StallTimeout)
+	require.Equal(t, DefaultUpgradeHaltTimeout, opts.HaltTimeout)
+	require.Equal(t, DefaultBlocksAfterUpgrade, opts.BlocksAfterUpgrade)
+	require.Equal(t, DefaultUpgradeResumeTimeout, opts.ResumeTimeout)
+
+	opts = UpgradeOptions{Method: UpgradeHaltHeight, HeightDelta: 5, StallTimeout: time.Second}.withDefaults()
+	require.Equal(t, UpgradeHaltHeight, opts.Method)
+	require.EqualValues(t, 5, opts.HeightDelta)
+	require.Equal(t, time.Second, opts.StallTimeout)
+}
+
+func TestUpgradeError(t *testing.T) {
+	err := error(&UpgradeError{
+		Step:  UpgradeStep{Name: "v2", Version: "v2.0.0"},
+		Phase: UpgradePhaseHalt,
+		Err:   context.DeadlineExceeded,
+	})
+	require.EqualError(t, err, `upgrade "v2" to version v2.0.0: halt: context deadline exceeded`)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	var upgradeErr *UpgradeError
+	require.True(t, errors.As(err, &upgradeErr))
+	require.Equal(t, UpgradePhaseHalt, upgradeErr.Phase)
+}
@@ -0,0 +1,316 @@
+package cosmos
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	cmttypes "github.com/cometbft/cometbft/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/strangelove-ventures/interchaintest/v8/dockerutil"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/strangelove-ventures/interchaintest/v8/testutil"
+)
+
+// DefaultValidatorSetMaxBlocks is the default number of blocks to wait for the CometBFT validator set to reflect a change.
+// A change of the staking module takes effect in the validator set two blocks after its end block.
+const DefaultValidatorSetMaxBlocks = 10
+
+// AddValidatorOptions configures a validator added with AddValidator.
+type AddValidatorOptions struct {
+	// Amount is the amount sent by the first validator to the account of the new validator,
+	// and SelfDelegation the part of it bonded by the new validator.
+	// They default to the genesis amounts of a validator of the same index, see ibc.ChainConfig.ModifyGenesisAmounts.
+	Amount         sdk.Coin
+	SelfDelegation sdk.Coin
+
+	// SyncTimeout bounds the wait for the node to catch up with the chain before it creates its validator.
+	// Defaults to DefaultStateSyncTimeout.
+	SyncTimeout time.Duration
+}
+
+// AddValidator starts a new validator node once the chain is running: the node catches up with the chain as a full node,
+// its validator key is funded by the first validator, and it bonds the self-delegation with a create-validator transaction.
+// The node is appended to Validators, and is returned once it is in the CometBFT validator set.
+//
+// The config file overrides of the chain apply to the new node, as to the validators at genesis.
+//
+// The consensus key of a running validator cannot be rotated, since the staking module of SDK v0.50 has no message for it.
+// To replace a consensus key, add a new validator with AddValidator and remove the old one with RemoveValidator.
+//
+// AddValidator and RemoveValidator are not safe for concurrent use, with each other
+// or with other methods of the chain that use Validators.
+func (c *CosmosChain) AddValidator(ctx context.Context, opts AddValidatorOptions) (*ChainNode, error) {
+	peers := c.Nodes().PeerString(ctx)
+	genbz, err := c.Validators[0].GenesisFileContent(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	index := 0
+	for _, vals := range []ChainNodes{c.Validators, c.removedValidators} {
+		for _, v := range vals {
+			index = max(index, v.Index+1)
+		}
+	}
+	amount, selfDelegation := c.validatorAmounts(index)
+	if !opts.Amount.IsNil() {
+		amount = opts.Amount
+	}
+	if !opts.SelfDelegation.IsNil() {
+		selfDelegation = opts.SelfDelegation
+	}
+	if opts.SyncTimeout <= 0 {
+		opts.SyncTimeout = DefaultStateSyncTimeout
+	}
+
+	v0 := c.Validators[0]
+	val, err := c.NewChainNode(ctx, c.testName, v0.DockerClient, v0.NetworkID, c.cfg.Images[0], true, index)
+	if err != nil {
+		return nil, err
+	}
+	if err := val.InitFullNodeFiles(ctx); err != nil {
+		return nil, err
+	}
+	if err := val.SetPeers(ctx, peers); err != nil {
+		return nil, err
+	}
+	if err := val.OverwriteGenesisFile(ctx, genbz); err != nil {
+		return nil, err
+	}
+	if err := val.modifyConfigFiles(ctx, c.cfg.ConfigFileOverrides); err != nil {
+		return nil, err
+	}
+	if err := val.CreateNodeContainer(ctx); err != nil {
+		return nil, err
+	}
+	if err := val.StartContainer(ctx); err != nil {
+		return nil, err
+	}
+
+	c.Validators = append(c.Validators, val)
+	c.NumValidators = len(c.Validators)
+
+	syncCtx, cancel := context.WithTimeout(ctx, opts.SyncTimeout)
+	defer cancel()
+	if err := testutil.WaitForInSync(syncCtx, c, val); err != nil {
+		return val, fmt.Errorf("validator %s did not catch up with the chain: %w", val.Name(), err)
+	}
+
+	if err := val.CreateKey(ctx, valKey); err != nil {
+		return val, err
+	}
+	addr, err := val.AccountKeyBech32(ctx, valKey)
+	if err != nil {
+		return val, err
+	}
+	if err := v0.BankSend(ctx, valKey, ibc.WalletAmount{Address: addr, Denom: amount.Denom, Amount: amount.Amount}); err != nil {
+		return val, fmt.Errorf("failed to fund validator %s: %w", val.Name(), err)
+	}
+
+	pubKey, _, err := val.ExecBin(ctx, "tendermint", "show-validator")
+	if err != nil {
+		return val, fmt.Errorf("failed to get validator pubkey: %w", err)
+	}
+	const valFile = "create-validator.json"
+	if err := val.StakingCreateValidatorFile(ctx, valFile,
+		strings.TrimSpace(string(pubKey)), selfDelegation.String(), CondenseMoniker(val.Name()),
+		"", "", "", "", "0.1", "0.2", "0.01", "1",
+	); err != nil {
+		return val, err
+	}
+	if err := val.StakingCreateValidator(ctx, valKey, path.Join(val.HomeDir(), valFile)); err != nil {
+		return val, fmt.Errorf("failed to create validator %s: %w", val.Name(), err)
+	}
+
+	consAddr, err := val.ConsensusAddress(ctx)
+	if err != nil {
+		return val, err
+	}
+	return val, c.WaitForCometValidator(ctx, consAddr, true, DefaultValidatorSetMaxBlocks)
+}
+
+// RemoveValidator unbonds the self-delegation of val, which removes it from the validator set,
+// then removes it from Validators and stops its node.
+// If the unbonding fails, val stays in Validators.
+// Delegations of other accounts to val stay unbonding or bonded to an unbonded validator.
+// Like AddValidator, it is not safe for concurrent use.
+func (c *CosmosChain) RemoveValidator(ctx context.Context, val *ChainNode) error {
+	i := -1
+	for j, v := range c.Validators {
+		if v == val {
+			i = j
+		}
+	}
+	switch {
+	case i < 0:
+		return fmt.Errorf("%s is not a validator of the chain", val.Name())
+	case len(c.Validators) == 1:
+		return errors.New("cannot remove the only validator of the chain")
+	}
+
+	consAddr, err := val.ConsensusAddress(ctx)
+	if err != nil {
+		return err
+	}
+	addr, err := val.AccountKeyBech32(ctx, valKey)
+	if err != nil {
+		return err
+	}
+	valoper, err := val.KeyBech32(ctx, valKey, "val")
+	if err != nil {
+		return err
+	}
+	delegation, err := c.StakingQueryDelegation(ctx, valoper, addr)
+	if err != nil {
+		return fmt.Errorf("failed to query self-delegation of %s: %w", valoper, err)
+	}
+
+	if err := val.StakingUnbond(ctx, valKey, valoper, delegation.Balance.String()); err != nil {
+		return fmt.Errorf("failed to unbond validator %s: %w", val.Name(), err)
+	}
+	if err := c.WaitForCometValidator(ctx, consAddr, false, DefaultValidatorSetMaxBlocks); err != nil {
+		return err
+	}
+
+	// Remove val once it left the validator set, and before it stops, so that the chain queries another node.
+	c.Validators = append(c.Validators[:i:i], c.Validators[i+1:]...)
+	c.NumValidators = len(c.Validators)
+	c.removedValidators = append(c.removedValidators, val)
+
+	if err := val.StopContainer(ctx); err != nil {
+		return err
+	}
+	return val.RemoveContainer(ctx)
+}
+
+// NewConsensusKey generates a consensus key in a scratch home on the volume of the node, without using it.
+// It returns the JSON encoded public key, as expected by create-validator or consumer key assignment transactions,
+// and the content of the priv_validator_key.json of the key.
+func (tn *ChainNode) NewConsensusKey(ctx context.Context) (string, []byte, error) {
+	cfg := tn.Chain.Config()
+	dir := "consensus-key-" + dockerutil.RandLowerCaseLetterString(8)
+	home := path.Join(tn.HomeDir(), dir)
+	if _, _, err := tn.Exec(ctx, []string{
+		cfg.Bin, "init", CondenseMoniker(tn.Name()), "--chain-id", cfg.ChainID, "--home", home,
+	}, cfg.Env); err != nil {
+		return "", nil, fmt.Errorf("failed to generate consensus key: %w", err)
+	}
+	pubKey, _, err := tn.Exec(ctx, []string{cfg.Bin, "tendermint", "show-validator", "--home", home}, cfg.Env)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get consensus pubkey: %w", err)
+	}
+	privVal, err := tn.ReadFile(ctx, path.Join(dir, "config", "priv_validator_key.json"))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read consensus key: %w", err)
+	}
+	return strings.TrimSpace(string(pubKey)), privVal, nil
+}
+
+// restartWithPrivVal restarts the node signing with the consensus key of privVal.
+func (tn *ChainNode) restartWithPrivVal(ctx context.Context, privVal []byte) error {
+	if err := tn.StopContainer(ctx); err != nil {
+		return err
+	}
+	if err := tn.OverwritePrivValFile(ctx, privVal); err != nil {
+		return err
+	}
+	return tn.StartContainer(ctx)
+}
+
+// ConsensusAddress returns the hex address of the consensus key of the node, as in the CometBFT validator set.
+func (tn *ChainNode) ConsensusAddress(ctx context.Context) (string, error) {
+	addr, _, err := tn.consensusKey(ctx)
+	return addr, err
+}
+
+// consensusKey returns the hex address and the base64 public key of the consensus key of the node.
+func (tn *ChainNode) consensusKey(ctx context.Context) (address, pubKey string, err error) {
+	bz, err := tn.PrivValFileContent(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	var privVal struct {
+		Address string `json:"address"`
+		PubKey  struct {
+			Value string `json:"value"`
+		} `json:"pub_key"`
+	}
+	if err := json.Unmarshal(bz, &privVal); err != nil {
+		return "", "", fmt.Errorf("malformed priv_validator_key.json: %w", err)
+	}
+	return strings.ToUpper(privVal.Address), privVal.PubKey.Value, nil
+}
+
+// CometValidators returns the CometBFT validator set of the latest block.
+func (c *CosmosChain) CometValidators(ctx context.Context) ([]*cmttypes.Validator, error) {
+	var (
+		vals    []*cmttypes.Validator
+		page    = 1
+		perPage = 100
+	)
+	for {
+		res, err := c.GetNode().Client.Validators(ctx, nil, &page, &perPage)
+		if err != nil {
+			return nil, err
+		}
+		vals = append(vals, res.Validators...)
+		if len(vals) >= res.Total || len(res.Validators) == 0 {
+			return vals, nil
+		}
+		page++
+	}
+}
+
+// WaitForCometValidator waits at most maxBlocks blocks until the validator with the hex consensus address consAddr
+// is in the CometBFT validator set if inSet is true, or is no longer in it if inSet is false.
+func (c *CosmosChain) WaitForCometValidator(ctx context.Context, consAddr string, inSet bool, maxBlocks int) error {
+	consAddr = strings.ToUpper(consAddr)
+	return testutil.WaitForBlocksUtil(maxBlocks, func(int) error {
+		vals, err := c.CometValidators(ctx)
+		if err == nil {
+			found := false
+			for _, v := range vals {
+				if v.Address.String() == consAddr {
+					found = true
+				}
+			}
+			if found == inSet {
+				return nil
+			}
+			err = fmt.Errorf("validator %s in validator set: %t, want %t", consAddr, found, inSet)
+		}
+		if waitErr := testutil.WaitForBlocks(ctx, 1, c); waitErr != nil {
+			return waitErr
+		}
+		return err
+	})
+}
+
+// modifyConfigFiles applies config file overrides, such as ibc.ChainConfig.ConfigFileOverrides, to the node.
+func (tn *ChainNode) modifyConfigFiles(ctx context.Context, overrides map[string]any) error {
+	for configFile, modifiedConfig := range overrides {
+		modifiedToml, ok := modifiedConfig.(testutil.Toml)
+		if !ok {
+			return fmt.Errorf("provided toml override for file %s is of type (%T). Expected (DecodedToml)", configFile, modifiedConfig)
+		}
+		if err := testutil.ModifyTomlConfigFile(
+			ctx,
+			tn.logger(),
+			tn.DockerClient,
+			tn.TestName,
+			tn.VolumeName,
+			configFile,
+			modifiedToml,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,274 @@
+// Package ibcquery implements ibc.IBCQuerier over the ibc-go gRPC query services,
+// for the chain implementations that serve them.
+package ibcquery
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+	conntypes "github.com/cosmos/ibc-go/v8/modules/core/03-connection/types"
+	chantypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+	ibcexported "github.com/cosmos/ibc-go/v8/modules/core/exported"
+	solomachine "github.com/cosmos/ibc-go/v8/modules/light-clients/06-solomachine"
+	ibctm "github.com/cosmos/ibc-go/v8/modules/light-clients/07-tendermint"
+	localhost "github.com/cosmos/ibc-go/v8/modules/light-clients/09-localhost"
+
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+)
+
+var _ ibc.IBCQuerier = (*Querier)(nil)
+
+// Querier queries the IBC state of a chain through a gRPC connection.
+type Querier struct {
+	conn     grpc.ClientConnInterface
+	registry codectypes.InterfaceRegistry
+}
+
+// New returns a Querier using conn.
+// The client and consensus states are unpacked with registry,
+// or with a registry of the ibc-go light clients if registry is nil.
+func New(conn grpc.ClientConnInterface, registry codectypes.InterfaceRegistry) *Querier {
+	if registry == nil {
+		registry = defaultRegistry()
+	}
+	return &Querier{conn: conn, registry: registry}
+}
+
+func defaultRegistry() codectypes.InterfaceRegistry {
+	registry := codectypes.NewInterfaceRegistry()
+	clienttypes.RegisterInterfaces(registry)
+	solomachine.RegisterInterfaces(registry)
+	ibctm.RegisterInterfaces(registry)
+	localhost.RegisterInterfaces(registry)
+	return registry
+}
+
+// QueryClientStates implements ibc.IBCQuerier.
+// Each client state is unpacked with the registry of q.
+func (q *Querier) QueryClientStates(ctx context.Context) (clienttypes.IdentifiedClientStates, error) {
+	states, err := paginate(ctx, func(page *query.PageRequest) ([]clienttypes.IdentifiedClientState, *query.PageResponse, error) {
+		res, err := clienttypes.NewQueryClient(q.conn).ClientStates(ctx, &clienttypes.QueryClientStatesRequest{Pagination: page})
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.ClientStates, res.Pagination, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Cache the unpacked client states, for use with clienttypes.UnpackClientState.
+	for _, cs := range states {
+		if err := cs.UnpackInterfaces(q.registry); err != nil {
+			return nil, err
+		}
+	}
+	return states, nil
+}
+
+// QueryClientState implements ibc.IBCQuerier.
+func (q *Querier) QueryClientState(ctx context.Context, clientID string) (ibcexported.ClientState, error) {
+	res, err := clienttypes.NewQueryClient(q.conn).ClientState(ctx, &clienttypes.QueryClientStateRequest{ClientId: clientID})
+	if err != nil {
+		return nil, err
+	}
+	var cs ibcexported.ClientState
+	if err := q.registry.UnpackAny(res.ClientState, &cs); err != nil {
+		return nil, err
+	}
+	return cs, nil
+}
+
+// QueryClientStatus implements ibc.IBCQuerier.
+func (q *Querier) QueryClientStatus(ctx context.Context, clientID string) (ibcexported.Status, error) {
+	res, err := clienttypes.NewQueryClient(q.conn).ClientStatus(ctx, &clienttypes.QueryClientStatusRequest{ClientId: clientID})
+	if err != nil {
+		return "", err
+	}
+	return ibcexported.Status(res.Status), nil
+}
+
+// QueryConsensusState implements ibc.IBCQuerier.
+func (q *Querier) QueryConsensusState(ctx context.Context, clientID string, height clienttypes.Height) (ibcexported.ConsensusState, error) {
+	res, err := clienttypes.NewQueryClient(q.conn).ConsensusState(ctx, &clienttypes.QueryConsensusStateRequest{
+		ClientId:       clientID,
+		RevisionNumber: height.RevisionNumber,
+		RevisionHeight: height.RevisionHeight,
+		LatestHeight:   height.IsZero(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	var cs ibcexported.ConsensusState
+	if err := q.registry.UnpackAny(res.ConsensusState, &cs); err != nil {
+		return nil, err
+	}
+	return cs, nil
+}
+
+// QueryConnections implements ibc.IBCQuerier.
+func (q *Querier) QueryConnections(ctx context.Context) ([]*conntypes.IdentifiedConnection, error) {
+	return paginate(ctx, func(page *query.PageRequest) ([]*conntypes.IdentifiedConnection, *query.PageResponse, error) {
+		res, err := conntypes.NewQueryClient(q.conn).Connections(ctx, &conntypes.QueryConnectionsRequest{Pagination: page})
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Connections, res.Pagination, nil
+	})
+}
+
+// QueryConnection implements ibc.IBCQuerier.
+func (q *Querier) QueryConnection(ctx context.Context, connectionID string) (*conntypes.ConnectionEnd, error) {
+	res, err := conntypes.NewQueryClient(q.conn).Connection(ctx, &conntypes.QueryConnectionRequest{ConnectionId: connectionID})
+	if err != nil {
+		return nil, err
+	}
+	return res.Connection, nil
+}
+
+// QueryChannels implements ibc.IBCQuerier.
+func (q *Querier) QueryChannels(ctx context.Context) ([]*chantypes.IdentifiedChannel, error) {
+	return paginate(ctx, func(page *query.PageRequest) ([]*chantypes.IdentifiedChannel, *query.PageResponse, error) {
+		res, err := chantypes.NewQueryClient(q.conn).Channels(ctx, &chantypes.QueryChannelsRequest{Pagination: page})
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Channels, res.Pagination, nil
+	})
+}
+
+// QueryChannel implements ibc.IBCQuerier.
+func (q *Querier) QueryChannel(ctx context.Context, portID, channelID string) (*chantypes.Channel, error) {
+	res, err := chantypes.NewQueryClient(q.conn).Channel(ctx, &chantypes.QueryChannelRequest{PortId: portID, ChannelId: channelID})
+	if err != nil {
+		return nil, err
+	}
+	return res.Channel, nil
+}
+
+// ChannelClientState returns the ID and state of the client that the channel is built on.
+func (q *Querier) ChannelClientState(ctx context.Context, portID, channelID string) (string, ibcexported.ClientState, error) {
+	res, err := chantypes.NewQueryClient(q.conn).ChannelClientState(ctx, &chantypes.QueryChannelClientStateRequest{PortId: portID, ChannelId: channelID})
+	if err != nil {
+		return "", nil, err
+	}
+	if res.IdentifiedClientState == nil {
+		return "", nil, fmt.Errorf("no client state for channel %s/%s", portID, channelID)
+	}
+	var cs ibcexported.ClientState
+	if err := q.registry.UnpackAny(res.IdentifiedClientState.ClientState, &cs); err != nil {
+		return "", nil, err
+	}
+	return res.IdentifiedClientState.ClientId, cs, nil
+}
+
+// QueryPacketCommitments implements ibc.IBCQuerier.
+func (q *Querier) QueryPacketCommitments(ctx context.Context, portID, channelID string) ([]*chantypes.PacketState, error) {
+	return paginate(ctx, func(page *query.PageRequest) ([]*chantypes.PacketState, *query.PageResponse, error) {
+		res, err := chantypes.NewQueryClient(q.conn).PacketCommitments(ctx, &chantypes.QueryPacketCommitmentsRequest{
+			PortId:     portID,
+			ChannelId:  channelID,
+			Pagination: page,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Commitments, res.Pagination, nil
+	})
+}
+
+// QueryPacketCommitment implements ibc.IBCQuerier.
+func (q *Querier) QueryPacketCommitment(ctx context.Context, portID, channelID string, sequence uint64) ([]byte, error) {
+	res, err := chantypes.NewQueryClient(q.conn).PacketCommitment(ctx, &chantypes.QueryPacketCommitmentRequest{
+		PortId:    portID,
+		ChannelId: channelID,
+		Sequence:  sequence,
+	})
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return res.Commitment, nil
+}
+
+// QueryPacketReceipt implements ibc.IBCQuerier.
+func (q *Querier) QueryPacketReceipt(ctx context.Context, portID, channelID string, sequence uint64) (bool, error) {
+	res, err := chantypes.NewQueryClient(q.conn).PacketReceipt(ctx, &chantypes.QueryPacketReceiptRequest{
+		PortId:    portID,
+		ChannelId: channelID,
+		Sequence:  sequence,
+	})
+	if err != nil {
+		return false, err
+	}
+	return res.Received, nil
+}
+
+// QueryPacketAcknowledgements implements ibc.IBCQuerier.
+func (q *Querier) QueryPacketAcknowledgements(ctx context.Context, portID, channelID string) ([]*chantypes.PacketState, error) {
+	return paginate(ctx, func(page *query.PageRequest) ([]*chantypes.PacketState, *query.PageResponse, error) {
+		res, err := chantypes.NewQueryClient(q.conn).PacketAcknowledgements(ctx, &chantypes.QueryPacketAcknowledgementsRequest{
+			PortId:     portID,
+			ChannelId:  channelID,
+			Pagination: page,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return res.Acknowledgements, res.Pagination, nil
+	})
+}
+
+// QueryPacketAcknowledgement implements ibc.IBCQuerier.
+func (q *Querier) QueryPacketAcknowledgement(ctx context.Context, portID, channelID string, sequence uint64) ([]byte, error) {
+	res, err := chantypes.NewQueryClient(q.conn).PacketAcknowledgement(ctx, &chantypes.QueryPacketAcknowledgementRequest{
+		PortId:    portID,
+		ChannelId: channelID,
+		Sequence:  sequence,
+	})
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return res.Acknowledgement, nil
+}
+
+// paginate calls fetch for every page of a paginated query, and returns the results of all pages.
+func paginate[T any](ctx context.Context, fetch func(*query.PageRequest) ([]T, *query.PageResponse, error)) ([]T, error) {
+	var (
+		all  []T
+		page = &query.PageRequest{}
+	)
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		items, res, err := fetch(page)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+
+		if res == nil || len(res.NextKey) == 0 {
+			return all, nil
+		}
+		page = &query.PageRequest{Key: res.NextKey}
+	}
+}
+
+// isNotFound reports whether err is a gRPC NotFound error.
+func isNotFound(err error) bool {
+	return err != nil && status.Code(err) == codes.NotFound
+}
@@ -0,0 +1,45 @@
+package ibcquery
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/cosmos/cosmos-sdk/types/query"
+)
+
+func TestPaginate(t *testing.T) {
+	pages := [][]int{{1, 2}, {3}, {4, 5}}
+
+	var keys [][]byte
+	all, err := paginate(context.Background(), func(page *query.PageRequest) ([]int, *query.PageResponse, error) {
+		keys = append(keys, page.Key)
+		i := len(keys) - 1
+		res := &query.PageResponse{}
+		if i < len(pages)-1 {
+			res.NextKey = []byte{byte(i + 1)}
+		}
+		return pages[i], res, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2, 3, 4, 5}, all)
+	require.Equal(t, [][]byte{nil, {1}, {2}}, keys)
+}
+
+func TestPaginateError(t *testing.T) {
+	wantErr := errors.New("boom")
+	_, err := paginate(context.Background(), func(*query.PageRequest) ([]int, *query.PageResponse, error) {
+		return nil, nil, wantErr
+	})
+	require.ErrorIs(t, err, wantErr)
+}
+
+func TestIsNotFound(t *testing.T) {
+	require.True(t, isNotFound(status.Error(codes.NotFound, "packet commitment not found")))
+	require.False(t, isNotFound(status.Error(codes.Unavailable, "connection refused")))
+	require.False(t, isNotFound(nil))
+}
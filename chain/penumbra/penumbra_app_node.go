@@ -13,6 +13,8 @@ import (
 	"github.com/docker/go-connections/nat"
 	"github.com/moby/moby/client"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 
 	"github.com/strangelove-ventures/interchaintest/v8/dockerutil"
 	"github.com/strangelove-ventures/interchaintest/v8/ibc"
@@ -30,6 +32,9 @@ type PenumbraAppNode struct {
 	DockerClient *client.Client
 	Image        ibc.DockerImage
 
+	// GRPCConn is a connection to the gRPC server of pd, set during StartContainer and closed by StopContainer.
+	GRPCConn *grpc.ClientConn
+
 	containerLifecycle *dockerutil.ContainerLifecycle
 
 	// Set during StartContainer.
@@ -358,14 +363,27 @@ func (p *PenumbraAppNode) CreateNodeContainer(ctx context.Context, tendermintAdd
 	return p.containerLifecycle.CreateContainer(ctx, p.TestName, p.NetworkID, p.Image, exposedPorts, "", p.Bind(), nil, p.HostName(), cmd, p.Chain.Config().Env, []string{})
 }
 
-// StopContainer stops the running container for the PenumbraAppNode.
+// StopContainer stops the running container for the PenumbraAppNode, and closes GRPCConn.
 func (p *PenumbraAppNode) StopContainer(ctx context.Context) error {
-	return p.containerLifecycle.StopContainer(ctx)
+	if err := p.containerLifecycle.StopContainer(ctx); err != nil {
+		return err
+	}
+	return p.closeGRPCConn()
+}
+
+// closeGRPCConn closes GRPCConn, if it is connected.
+func (p *PenumbraAppNode) closeGRPCConn() error {
+	if p.GRPCConn == nil {
+		return nil
+	}
+	err := p.GRPCConn.Close()
+	p.GRPCConn = nil
+	return err
 }
 
 // StartContainer starts the test node container, if an error occurs it is returned.
-// The obtained host ports are assigned to the hostRPCPort and hostGRPCPort fields of the PenumbraAppNode struct.
-// Finally, nil is returned if everything is successful.
+// The obtained host ports are assigned to the hostRPCPort and hostGRPCPort fields of the PenumbraAppNode struct,
+// and GRPCConn is connected to the gRPC port. Finally, nil is returned if everything is successful.
 func (p *PenumbraAppNode) StartContainer(ctx context.Context) error {
 	if err := p.containerLifecycle.StartContainer(ctx); err != nil {
 		return err
@@ -378,6 +396,15 @@ func (p *PenumbraAppNode) StartContainer(ctx context.Context) error {
 
 	p.hostRPCPort, p.hostGRPCPort = hostPorts[0], hostPorts[1]
 
+	// The host ports change when the container is restarted, so replace the connection of a previous start.
+	if err := p.closeGRPCConn(); err != nil {
+		return err
+	}
+	p.GRPCConn, err = grpc.NewClient(p.hostGRPCPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
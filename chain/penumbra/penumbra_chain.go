@@ -24,6 +24,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/crypto/hd"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
 
+	"github.com/strangelove-ventures/interchaintest/v8/chain/internal/ibcquery"
 	"github.com/strangelove-ventures/interchaintest/v8/chain/internal/tendermint"
 	"github.com/strangelove-ventures/interchaintest/v8/dockerutil"
 	"github.com/strangelove-ventures/interchaintest/v8/ibc"
@@ -117,6 +118,14 @@ func (c *PenumbraChain) Exec(ctx context.Context, cmd []string, env []string) (s
 	return c.getFullNode().PenumbraAppNode.Exec(ctx, cmd, env)
 }
 
+var _ ibc.IBCQueryChain = (*PenumbraChain)(nil)
+
+// IBCQuerier implements ibc.IBCQueryChain.
+// The client and consensus states are unpacked with the ibc-go light clients.
+func (c *PenumbraChain) IBCQuerier() ibc.IBCQuerier {
+	return ibcquery.New(c.getFullNode().PenumbraAppNode.GRPCConn, nil)
+}
+
 // getFullNode returns the first configured validator node in the network.
 func (c *PenumbraChain) getFullNode() *PenumbraNode {
 	// use first validator
@@ -33,6 +33,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/types"
 
 	"github.com/strangelove-ventures/interchaintest/v8/blockdb"
+	"github.com/strangelove-ventures/interchaintest/v8/chain/internal/ibcquery"
 	"github.com/strangelove-ventures/interchaintest/v8/dockerutil"
 	"github.com/strangelove-ventures/interchaintest/v8/ibc"
 	"github.com/strangelove-ventures/interchaintest/v8/testutil"
@@ -391,6 +392,13 @@ func (c *Thorchain) Exec(ctx context.Context, cmd []string, env []string) (stdou
 	return c.getFullNode().Exec(ctx, cmd, env)
 }
 
+var _ ibc.IBCQueryChain = (*Thorchain)(nil)
+
+// IBCQuerier implements ibc.IBCQueryChain.
+func (c *Thorchain) IBCQuerier() ibc.IBCQuerier {
+	return ibcquery.New(c.GetNode().GrpcConn, c.Config().EncodingConfig.InterfaceRegistry)
+}
+
 // Implements Chain interface.
 func (c *Thorchain) GetRPCAddress() string {
 	if c.Config().UsesCometMock() {
@@ -145,6 +145,26 @@ func TestChainSpec_Config(t *testing.T) {
 
 			require.Equal(t, m, cfg.NoHostMount)
 		})
+
+		t.Run("TxMode", func(t *testing.T) {
+			require.Empty(t, baseCfg.TxMode)
+
+			s := &interchaintest.ChainSpec{
+				Name:    baseSpec.Name,
+				Version: baseSpec.Version,
+
+				ChainName: baseSpec.ChainName,
+				ChainConfig: ibc.ChainConfig{
+					ChainID: baseSpec.ChainID,
+					TxMode:  ibc.TxModeGRPC,
+				},
+			}
+
+			cfg, err := s.Config(zaptest.NewLogger(t))
+			require.NoError(t, err)
+
+			require.Equal(t, ibc.TxModeGRPC, cfg.TxMode)
+		})
 	})
 
 	t.Run("error cases", func(t *testing.T) {
@@ -0,0 +1,183 @@
+package conformance
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	ibcexported "github.com/cosmos/ibc-go/v8/modules/core/exported"
+
+	"github.com/strangelove-ventures/interchaintest/v8"
+	"github.com/strangelove-ventures/interchaintest/v8/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/strangelove-ventures/interchaintest/v8/relayer"
+	"github.com/strangelove-ventures/interchaintest/v8/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v8/testutil"
+)
+
+// expiryTrustingPeriod is the trusting period of the clients created by TestRelayerClientExpiry.
+// It must be long enough for the connection and channel handshakes to complete before the clients expire.
+const expiryTrustingPeriod = 90 * time.Second
+
+// TestRelayerClientExpiry asserts that clients created with a short trusting period expire
+// when they are not updated, and that the relayer then fails to update them.
+//
+// Both chains must be Cosmos chains, since the client status is queried from the chains.
+func TestRelayerClientExpiry(t *testing.T, ctx context.Context, cf interchaintest.ChainFactory, rf interchaintest.RelayerFactory, rep *testreporter.Reporter) {
+	rep.TrackTest(t)
+
+	requireCapabilities(t, rep, rf, relayer.ClientExpiry)
+
+	const pathName = "p"
+	client, network := interchaintest.DockerSetup(t)
+
+	// The relayer is never started, so the clients are not updated after the handshakes.
+	c0, c1, r := buildChainPair(t, ctx, client, network, cf, rf, rep, pathName, ibc.DefaultChannelOpts(), ibc.CreateClientOptions{
+		TrustingPeriod: expiryTrustingPeriod.String(),
+	})
+	cc0, cc1 := cosmosChainPair(t, rep, c0, c1)
+
+	req := require.New(rep.TestifyT(t))
+	eRep := rep.RelayerExecReporter(t)
+
+	clientID0, err := counterpartyClientID(ctx, r, eRep, cc0, cc1)
+	req.NoError(err)
+	clientID1, err := counterpartyClientID(ctx, r, eRep, cc1, cc0)
+	req.NoError(err)
+
+	req.NoError(waitForClientStatus(ctx, cc0, clientID0, ibcexported.Expired, expiryTrustingPeriod+time.Minute))
+	req.NoError(waitForClientStatus(ctx, cc1, clientID1, ibcexported.Expired, time.Minute))
+
+	req.Error(r.UpdateClients(ctx, eRep, pathName), "relayer updated expired clients")
+
+	for _, c := range []struct {
+		chain    *cosmos.CosmosChain
+		clientID string
+	}{{cc0, clientID0}, {cc1, clientID1}} {
+		status, err := clientStatus(ctx, c.chain, c.clientID)
+		req.NoError(err)
+		req.Equal(ibcexported.Expired.String(), status, "status of client %s on %s", c.clientID, c.chain.Config().ChainID)
+	}
+}
+
+// TestRelayerMisbehaviour asserts that the relayer submits misbehaviour
+// when a client is updated with a header of a fork of its counterparty,
+// which freezes the client.
+//
+// The fork is produced by an isolated duplicate of the validator of the first chain,
+// so both chains must be Cosmos chains and the first chain must have a single validator.
+// A second relayer from rf, configured against the duplicate, updates the client with the forked header.
+func TestRelayerMisbehaviour(t *testing.T, ctx context.Context, cf interchaintest.ChainFactory, rf interchaintest.RelayerFactory, rep *testreporter.Reporter) {
+	rep.TrackTest(t)
+
+	requireCapabilities(t, rep, rf, relayer.Misbehaviour)
+
+	const pathName = "p"
+	client, network := interchaintest.DockerSetup(t)
+
+	c0, c1, r := buildChainPair(t, ctx, client, network, cf, rf, rep, pathName, ibc.DefaultChannelOpts(), ibc.DefaultClientOpts())
+	src, dst := cosmosChainPair(t, rep, c0, c1)
+	if len(src.Validators) != 1 {
+		rep.TrackSkip(t, "skipping because forking %s requires a single validator, got %d", src.Config().ChainID, len(src.Validators))
+	}
+
+	req := require.New(rep.TestifyT(t))
+	eRep := rep.RelayerExecReporter(t)
+
+	srcClientID, err := counterpartyClientID(ctx, r, eRep, src, dst)
+	req.NoError(err)
+	dstClientID, err := counterpartyClientID(ctx, r, eRep, dst, src)
+	req.NoError(err)
+
+	// Keys of the fork relayer, funded before the fork so that they exist on both sides of it.
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "fork", userFaucetFund, src, dst)
+	req.NoError(testutil.WaitForBlocks(ctx, 2, src, dst))
+
+	req.NoError(r.StartRelayer(ctx, eRep, pathName))
+	t.Cleanup(func() {
+		_ = r.StopRelayer(ctx, eRep)
+	})
+
+	dup, err := src.AddDuplicateValidator(ctx, src.Validators[0], true)
+	req.NoError(err, "failed to start duplicate validator")
+	req.NoError(testutil.WaitForBlocks(ctx, 3, dup), "duplicate validator did not fork the chain")
+
+	// Configure a second relayer that sees the fork as the first chain.
+	forkRelayer := rf.Build(t, client, network)
+	const forkPathName = "fork"
+
+	req.NoError(forkRelayer.AddChainConfiguration(ctx, eRep, src.Config(), "src",
+		fmt.Sprintf("http://%s:26657", dup.HostName()), fmt.Sprintf("%s:9090", dup.HostName()),
+	))
+	req.NoError(forkRelayer.RestoreKey(ctx, eRep, src.Config(), "src", users[0].Mnemonic()))
+	req.NoError(forkRelayer.AddChainConfiguration(ctx, eRep, dst.Config(), "dst", dst.GetRPCAddress(), dst.GetGRPCAddress()))
+	req.NoError(forkRelayer.RestoreKey(ctx, eRep, dst.Config(), "dst", users[1].Mnemonic()))
+
+	req.NoError(forkRelayer.GeneratePath(ctx, eRep, src.Config().ChainID, dst.Config().ChainID, forkPathName))
+	req.NoError(forkRelayer.UpdatePath(ctx, eRep, forkPathName, ibc.PathUpdateOptions{
+		SrcClientID: &srcClientID,
+		DstClientID: &dstClientID,
+	}))
+
+	// Updating the client on dst with a header of the fork is the misbehaviour the relayer must catch.
+	req.NoError(forkRelayer.UpdateClients(ctx, eRep, forkPathName), "failed to update client with forked header")
+
+	req.NoError(waitForClientStatus(ctx, dst, dstClientID, ibcexported.Frozen, 2*time.Minute), "client was not frozen")
+
+	t.Run("frozen client", func(t *testing.T) {
+		rep.TrackTest(t)
+		requireCapabilities(t, rep, rf, relayer.FrozenClient)
+
+		req := require.New(rep.TestifyT(t))
+		eRep := rep.RelayerExecReporter(t)
+
+		req.Error(r.UpdateClients(ctx, eRep, pathName), "relayer updated a frozen client")
+
+		status, err := clientStatus(ctx, dst, dstClientID)
+		req.NoError(err)
+		req.Equal(ibcexported.Frozen.String(), status)
+	})
+}
+
+// counterpartyClientID returns the ID of the client on chain that tracks counterparty.
+func counterpartyClientID(ctx context.Context, r ibc.Relayer, eRep ibc.RelayerExecReporter, chain, counterparty ibc.Chain) (string, error) {
+	clients, err := r.GetClients(ctx, eRep, chain.Config().ChainID)
+	if err != nil {
+		return "", err
+	}
+	for _, c := range clients {
+		if c.ClientState.ChainID == counterparty.Config().ChainID {
+			return c.ClientID, nil
+		}
+	}
+	return "", fmt.Errorf("no client on %s tracks %s", chain.Config().ChainID, counterparty.Config().ChainID)
+}
+
+// clientStatus queries the status of the client from chain, e.g. "Active", "Expired" or "Frozen".
+func clientStatus(ctx context.Context, chain *cosmos.CosmosChain, clientID string) (string, error) {
+	status, err := chain.IBCQuerier().QueryClientStatus(ctx, clientID)
+	if err != nil {
+		return "", err
+	}
+	return status.String(), nil
+}
+
+// waitForClientStatus waits up to timeout until the client on chain has the given status.
+func waitForClientStatus(ctx context.Context, chain *cosmos.CosmosChain, clientID string, status ibcexported.Status, timeout time.Duration) error {
+	var last string
+	err := testutil.WaitForCondition(timeout, 5*time.Second, func() (bool, error) {
+		var err error
+		last, err = clientStatus(ctx, chain, clientID)
+		if err != nil {
+			return false, err
+		}
+		return last == status.String(), nil
+	})
+	if err != nil {
+		return fmt.Errorf("client %s on %s is %s, expected %s: %w", clientID, chain.Config().ChainID, last, status, err)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package conformance
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/math"
+
+	"github.com/strangelove-ventures/interchaintest/v8"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/strangelove-ventures/interchaintest/v8/relayer"
+	"github.com/strangelove-ventures/interchaintest/v8/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v8/testutil"
+)
+
+// feeTransferVersion is the version of an ics20 channel wrapped in ICS-29 fee middleware.
+const feeTransferVersion = `{"fee_version":"ics29-1","app_version":"ics20-1"}`
+
+// TestRelayerFeeMiddleware asserts that the relayer relays over a channel wrapped in ICS-29 fee middleware,
+// and that the fees paid for a packet are distributed once it is acknowledged:
+// the receive fee to the counterparty payee registered for the relayer, and the timeout fee refunded to the payer.
+//
+// Both chains must be Cosmos chains with the ibc-fee module enabled.
+func TestRelayerFeeMiddleware(t *testing.T, ctx context.Context, cf interchaintest.ChainFactory, rf interchaintest.RelayerFactory, rep *testreporter.Reporter) {
+	rep.TrackTest(t)
+
+	requireCapabilities(t, rep, rf, relayer.FeeMiddleware)
+
+	channelOpts := ibc.DefaultChannelOpts()
+	channelOpts.Version = feeTransferVersion
+
+	const pathName = "p"
+	client, network := interchaintest.DockerSetup(t)
+
+	c0, c1, r := buildChainPair(t, ctx, client, network, cf, rf, rep, pathName, channelOpts, ibc.DefaultClientOpts())
+	src, dst := cosmosChainPair(t, rep, c0, c1)
+
+	req := require.New(rep.TestifyT(t))
+	eRep := rep.RelayerExecReporter(t)
+
+	ch, err := findChannel(ctx, r, eRep, src.Config().ChainID, channelOpts.SourcePortName)
+	req.NoError(err)
+	req.JSONEq(feeTransferVersion, ch.Version)
+
+	// The relayer submits MsgRecvPacket on dst, so its dst wallet registers where its receive fees are paid on src.
+	relayerWallet, ok := r.GetWallet(dst.Config().ChainID)
+	req.True(ok, "relayer has no wallet on %s", dst.Config().ChainID)
+	req.NotEmpty(relayerWallet.Mnemonic(), "relayer wallet on %s has no mnemonic", dst.Config().ChainID)
+
+	const relayerKeyName = "fee-relayer"
+	req.NoError(dst.RecoverKey(ctx, relayerKeyName, relayerWallet.Mnemonic()))
+
+	payer := interchaintest.GetAndFundTestUsers(t, ctx, "fee-payer", userFaucetFund, src)[0]
+	payee := interchaintest.GetAndFundTestUsers(t, ctx, "fee-payee", userFaucetFund, src)[0]
+	receiver := interchaintest.GetAndFundTestUsers(t, ctx, "fee-receiver", userFaucetFund, dst)[0]
+	req.NoError(testutil.WaitForBlocks(ctx, 2, src, dst))
+
+	_, err = dst.GetNode().ExecTx(ctx, relayerKeyName,
+		"ibc-fee", "register-counterparty-payee",
+		ch.Counterparty.PortID, ch.Counterparty.ChannelID, relayerWallet.FormattedAddress(), payee.FormattedAddress(),
+	)
+	req.NoError(err, "failed to register counterparty payee")
+
+	var (
+		denom      = src.Config().Denom
+		recvFee    = math.NewInt(1_000)
+		ackFee     = math.NewInt(500)
+		timeoutFee = math.NewInt(200)
+	)
+
+	payerKey := interchaintest.BalanceKey{Chain: src, Address: payer.FormattedAddress(), Denom: denom}
+	payeeKey := interchaintest.BalanceKey{Chain: src, Address: payee.FormattedAddress(), Denom: denom}
+	receiverKey := interchaintest.BalanceKey{Chain: dst, Address: receiver.FormattedAddress(), Denom: ibc.IBCDenom(denom, ch)}
+
+	snapshot, err := interchaintest.TakeBalanceSnapshot(ctx, payerKey, payeeKey, receiverKey)
+	req.NoError(err)
+
+	// The relayer has not been started yet, so the fee is paid before the packet is relayed.
+	tx, err := src.SendIBCTransfer(ctx, ch.ChannelID, payer.KeyName(), ibc.WalletAmount{
+		Address: receiver.FormattedAddress(),
+		Denom:   denom,
+		Amount:  testCoinAmount,
+	}, ibc.TransferOptions{})
+	req.NoError(err)
+	req.NoError(tx.Validate())
+
+	feeTxHash, err := src.GetNode().ExecTx(ctx, payer.KeyName(),
+		"ibc-fee", "pay-packet-fee", tx.Packet.SourcePort, tx.Packet.SourceChannel, strconv.FormatUint(tx.Packet.Sequence, 10),
+		"--recv-fee", recvFee.String()+denom,
+		"--ack-fee", ackFee.String()+denom,
+		"--timeout-fee", timeoutFee.String()+denom,
+	)
+	req.NoError(err, "failed to pay packet fee")
+	feeTx, err := src.GetTransaction(feeTxHash)
+	req.NoError(err)
+
+	req.NoError(r.StartRelayer(ctx, eRep, pathName))
+	t.Cleanup(func() {
+		_ = r.StopRelayer(ctx, eRep)
+	})
+
+	ack, err := testutil.PollForAck(ctx, src, tx.Height, tx.Height+pollHeightMax, tx.Packet)
+	req.NoError(err, "failed to get acknowledgement")
+	req.NoError(ack.Validate())
+
+	// Give balances time to settle after the acknowledgement.
+	req.NoError(testutil.WaitForBlocks(ctx, 2, src, dst))
+
+	// The ack fee is paid to the relayer that submitted the acknowledgement, which also paid gas for it,
+	// so only the payer, the counterparty payee and the receiver are asserted.
+	snapshot.RequireDeltas(ctx, rep.TestifyT(t),
+		interchaintest.BalanceDelta{BalanceKey: payerKey, Amount: testCoinAmount.Add(recvFee).Add(ackFee).Neg(), GasSpent: tx.GasSpent},
+		interchaintest.BalanceDelta{BalanceKey: payerKey, GasSpent: feeTx.GasWanted},
+		interchaintest.BalanceDelta{BalanceKey: payeeKey, Amount: recvFee},
+		interchaintest.BalanceDelta{BalanceKey: receiverKey, Amount: testCoinAmount},
+	)
+}
@@ -2,7 +2,6 @@ package conformance
 
 import (
 	"context"
-	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -25,43 +24,14 @@ func TestRelayerFlushing(t *testing.T, ctx context.Context, cf interchaintest.Ch
 	// but check that capability first in case we can avoid setup.
 	requireCapabilities(t, rep, rf, relayer.Flush)
 
+	const pathName = "p"
 	client, network := interchaintest.DockerSetup(t)
 
-	req := require.New(rep.TestifyT(t))
-	chains, err := cf.Chains(t.Name())
-	req.NoError(err, "failed to get chains")
-
-	if len(chains) != 2 {
-		panic(fmt.Errorf("expected 2 chains, got %d", len(chains)))
-	}
-
-	c0, c1 := chains[0], chains[1]
-
-	r := rf.Build(t, client, network)
-
-	const pathName = "p"
-	ic := interchaintest.NewInterchain().
-		AddChain(c0).
-		AddChain(c1).
-		AddRelayer(r, "r").
-		AddLink(interchaintest.InterchainLink{
-			Chain1:  c0,
-			Chain2:  c1,
-			Relayer: r,
-
-			Path:              pathName,
-			CreateChannelOpts: ibc.DefaultChannelOpts(),
-		})
+	c0, c1, r := buildChainPair(t, ctx, client, network, cf, rf, rep, pathName, ibc.DefaultChannelOpts(), ibc.DefaultClientOpts())
 
+	req := require.New(rep.TestifyT(t))
 	eRep := rep.RelayerExecReporter(t)
 
-	req.NoError(ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
-		TestName:  t.Name(),
-		Client:    client,
-		NetworkID: network,
-	}))
-	defer ic.Close()
-
 	// Get faucet address on destination chain for ibc transfer.
 	c1FaucetAddrBytes, err := c1.GetAddress(ctx, interchaintest.FaucetAccountKeyName)
 	req.NoError(err)
@@ -0,0 +1,118 @@
+package conformance
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	icatypes "github.com/cosmos/ibc-go/v8/modules/apps/27-interchain-accounts/types"
+
+	"github.com/strangelove-ventures/interchaintest/v8"
+	"github.com/strangelove-ventures/interchaintest/v8/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/strangelove-ventures/interchaintest/v8/relayer"
+	"github.com/strangelove-ventures/interchaintest/v8/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v8/testutil"
+)
+
+// TestRelayerOrderedChannel asserts that the relayer completes the handshake of an ordered channel,
+// and that a packet timeout on that channel closes it.
+//
+// The ordered channel is an interchain account channel registered by a user,
+// so both chains must be Cosmos chains with the interchain accounts controller and host enabled.
+//
+// Standard IBC applications reject a ChanCloseInit submitted by a user,
+// so closing is exercised through the timeout instead:
+// the timeout closes the controller end of the channel,
+// and the relayer is expected to close the host end with ChanCloseConfirm.
+func TestRelayerOrderedChannel(t *testing.T, ctx context.Context, cf interchaintest.ChainFactory, rf interchaintest.RelayerFactory, rep *testreporter.Reporter) {
+	rep.TrackTest(t)
+
+	requireCapabilities(t, rep, rf, relayer.OrderedChannel)
+
+	const pathName = "p"
+	client, network := interchaintest.DockerSetup(t)
+
+	c0, c1, r := buildChainPair(t, ctx, client, network, cf, rf, rep, pathName, ibc.DefaultChannelOpts(), ibc.DefaultClientOpts())
+	controller, host := cosmosChainPair(t, rep, c0, c1)
+
+	req := require.New(rep.TestifyT(t))
+	eRep := rep.RelayerExecReporter(t)
+
+	// The transfer channel created by the Interchain provides the connection for the interchain account.
+	transfer, err := findChannel(ctx, r, eRep, controller.Config().ChainID, "transfer")
+	req.NoError(err)
+	connectionID := transfer.ConnectionHops[0]
+
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "ordered", userFaucetFund, controller)
+	user := users[0]
+
+	req.NoError(r.StartRelayer(ctx, eRep, pathName))
+	t.Cleanup(func() {
+		_ = r.StopRelayer(ctx, eRep)
+	})
+
+	_, err = controller.ICARegister(ctx, user.KeyName(), connectionID, cosmos.ICARegisterOptions{Ordering: ibc.Ordered})
+	req.NoError(err, "failed to register interchain account")
+
+	var icaAddr string
+	req.NoError(testutil.WaitForCondition(2*time.Minute, 5*time.Second, func() (bool, error) {
+		// The query fails until the handshake completed.
+		icaAddr, _ = controller.ICAQueryAddress(ctx, user.FormattedAddress(), connectionID)
+		return icaAddr != "", nil
+	}), "interchain account was not created")
+
+	portID := icatypes.ControllerPortPrefix + user.FormattedAddress()
+	ch, err := findChannel(ctx, r, eRep, controller.Config().ChainID, portID)
+	req.NoError(err)
+
+	req.Subset([]string{"STATE_OPEN", "Open"}, []string{ch.State})
+	req.Subset([]string{"ORDER_ORDERED", "Ordered"}, []string{ch.Ordering})
+	req.Equal(icatypes.HostPortID, ch.Counterparty.PortID)
+
+	t.Run("timeout closes channel", func(t *testing.T) {
+		rep.TrackTest(t)
+		requireCapabilities(t, rep, rf, relayer.TimestampTimeout)
+
+		req := require.New(rep.TestifyT(t))
+		eRep := rep.RelayerExecReporter(t)
+
+		// Stop the relayer so that the packet cannot be received before it times out.
+		req.NoError(r.StopRelayer(ctx, eRep))
+
+		msg := &banktypes.MsgSend{
+			FromAddress: icaAddr,
+			ToAddress:   icaAddr,
+			Amount:      sdk.NewCoins(sdk.NewInt64Coin(host.Config().Denom, 1)),
+		}
+		_, err := controller.ICAExecute(ctx, user.KeyName(), connectionID, "", time.Second, msg)
+		req.NoError(err)
+
+		// Let the timeout elapse on the host.
+		req.NoError(testutil.WaitForBlocks(ctx, 5, host))
+
+		req.NoError(r.StartRelayer(ctx, eRep, pathName))
+
+		req.NoError(
+			waitForChannelState(ctx, r, eRep, controller.Config().ChainID, ch.PortID, ch.ChannelID, "STATE_CLOSED", "Closed"),
+			"controller channel end was not closed by the timeout",
+		)
+
+		t.Run("counterparty closed", func(t *testing.T) {
+			rep.TrackTest(t)
+			requireCapabilities(t, rep, rf, relayer.ChannelClose)
+
+			req := require.New(rep.TestifyT(t))
+			eRep := rep.RelayerExecReporter(t)
+
+			req.NoError(
+				waitForChannelState(ctx, r, eRep, host.Config().ChainID, ch.Counterparty.PortID, ch.Counterparty.ChannelID, "STATE_CLOSED", "Closed"),
+				"host channel end was not closed by the relayer",
+			)
+		})
+	})
+}
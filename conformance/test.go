@@ -31,6 +31,7 @@ package conformance
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -129,6 +130,104 @@ func missingCapabilities(rf interchaintest.RelayerFactory, reqCaps ...relayer.Ca
 	return missing
 }
 
+// buildChainPair starts the two chains of cf, linked by a relayer from rf over pathName
+// with clients and a channel created with the given options, for test cases that need their own chains.
+// The relayer is not started. The Interchain is closed when t completes.
+func buildChainPair(
+	t *testing.T,
+	ctx context.Context,
+	client *client.Client,
+	network string,
+	cf interchaintest.ChainFactory,
+	rf interchaintest.RelayerFactory,
+	rep *testreporter.Reporter,
+	pathName string,
+	channelOpts ibc.CreateChannelOptions,
+	clientOpts ibc.CreateClientOptions,
+) (ibc.Chain, ibc.Chain, ibc.Relayer) {
+	t.Helper()
+
+	req := require.New(rep.TestifyT(t))
+	chains, err := cf.Chains(t.Name())
+	req.NoError(err, "failed to get chains")
+
+	if len(chains) != 2 {
+		panic(fmt.Errorf("expected 2 chains, got %d", len(chains)))
+	}
+
+	c0, c1 := chains[0], chains[1]
+
+	r := rf.Build(t, client, network)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(c0).
+		AddChain(c1).
+		AddRelayer(r, "r").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  c0,
+			Chain2:  c1,
+			Relayer: r,
+
+			Path:              pathName,
+			CreateClientOpts:  clientOpts,
+			CreateChannelOpts: channelOpts,
+		})
+
+	req.NoError(ic.Build(ctx, rep.RelayerExecReporter(t), interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	return c0, c1, r
+}
+
+// cosmosChainPair returns both chains as CosmosChains,
+// or tracks skipping t if either chain is not a Cosmos chain.
+func cosmosChainPair(t *testing.T, rep *testreporter.Reporter, c0, c1 ibc.Chain) (*cosmos.CosmosChain, *cosmos.CosmosChain) {
+	t.Helper()
+
+	cc0, ok0 := c0.(*cosmos.CosmosChain)
+	cc1, ok1 := c1.(*cosmos.CosmosChain)
+	if !ok0 || !ok1 {
+		rep.TrackSkip(t, "skipping because both chains must be Cosmos chains")
+	}
+	return cc0, cc1
+}
+
+// findChannel returns the channel of the relayer's view of chainID with the given port ID.
+func findChannel(ctx context.Context, r ibc.Relayer, eRep ibc.RelayerExecReporter, chainID, portID string) (ibc.ChannelOutput, error) {
+	channels, err := r.GetChannels(ctx, eRep, chainID)
+	if err != nil {
+		return ibc.ChannelOutput{}, err
+	}
+	for _, ch := range channels {
+		if ch.PortID == portID {
+			return ch, nil
+		}
+	}
+	return ibc.ChannelOutput{}, fmt.Errorf("no channel with port %s on %s", portID, chainID)
+}
+
+// waitForChannelState waits until the channel of chainID with the given port and channel ID is in one of states.
+func waitForChannelState(ctx context.Context, r ibc.Relayer, eRep ibc.RelayerExecReporter, chainID, portID, channelID string, states ...string) error {
+	return testutil.WaitForCondition(2*time.Minute, 5*time.Second, func() (bool, error) {
+		channels, err := r.GetChannels(ctx, eRep, chainID)
+		if err != nil {
+			return false, err
+		}
+		for _, ch := range channels {
+			if ch.PortID == portID && ch.ChannelID == channelID {
+				return slices.Contains(states, ch.State), nil
+			}
+		}
+		return false, nil
+	})
+}
+
 func sendIBCTransfersFromBothChainsWithTimeout(
 	ctx context.Context,
 	t *testing.T,
@@ -265,6 +364,41 @@ func Test(t *testing.T, ctx context.Context, cfs []interchaintest.ChainFactory,
 
 								TestRelayerFlushing(t, ctx, cf, rf, rep)
 							})
+
+							t.Run("ordered channel", func(t *testing.T) {
+								rep.TrackTest(t)
+								rep.TrackParallel(t)
+
+								TestRelayerOrderedChannel(t, ctx, cf, rf, rep)
+							})
+
+							t.Run("fee middleware", func(t *testing.T) {
+								rep.TrackTest(t)
+								rep.TrackParallel(t)
+
+								TestRelayerFeeMiddleware(t, ctx, cf, rf, rep)
+							})
+
+							t.Run("channel upgrade", func(t *testing.T) {
+								rep.TrackTest(t)
+								rep.TrackParallel(t)
+
+								TestRelayerChannelUpgrade(t, ctx, cf, rf, rep)
+							})
+
+							t.Run("client expiry", func(t *testing.T) {
+								rep.TrackTest(t)
+								rep.TrackParallel(t)
+
+								TestRelayerClientExpiry(t, ctx, cf, rf, rep)
+							})
+
+							t.Run("misbehaviour", func(t *testing.T) {
+								rep.TrackTest(t)
+								rep.TrackParallel(t)
+
+								TestRelayerMisbehaviour(t, ctx, cf, rf, rep)
+							})
 						})
 					}
 				})
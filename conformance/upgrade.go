@@ -0,0 +1,133 @@
+package conformance
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	channeltypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+
+	"github.com/strangelove-ventures/interchaintest/v8"
+	"github.com/strangelove-ventures/interchaintest/v8/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/strangelove-ventures/interchaintest/v8/relayer"
+	"github.com/strangelove-ventures/interchaintest/v8/testreporter"
+	"github.com/strangelove-ventures/interchaintest/v8/testutil"
+)
+
+// maxUpgradeVotingPeriod is the longest gov voting period for which TestRelayerChannelUpgrade runs,
+// since the upgrade is initiated by a proposal that has to pass first.
+const maxUpgradeVotingPeriod = 30 * time.Second
+
+// TestRelayerChannelUpgrade asserts that the relayer completes a channel upgrade handshake,
+// by upgrading an ics20 channel to be wrapped in ICS-29 fee middleware.
+//
+// The upgrade is initiated on the first chain by a gov proposal, so both chains must be Cosmos chains
+// with the ibc-fee module enabled, and the first chain must have a voting period of at most 30 seconds.
+func TestRelayerChannelUpgrade(t *testing.T, ctx context.Context, cf interchaintest.ChainFactory, rf interchaintest.RelayerFactory, rep *testreporter.Reporter) {
+	rep.TrackTest(t)
+
+	requireCapabilities(t, rep, rf, relayer.ChannelUpgrade)
+
+	const pathName = "p"
+	client, network := interchaintest.DockerSetup(t)
+
+	c0, c1, r := buildChainPair(t, ctx, client, network, cf, rf, rep, pathName, ibc.DefaultChannelOpts(), ibc.DefaultClientOpts())
+	src, dst := cosmosChainPair(t, rep, c0, c1)
+
+	req := require.New(rep.TestifyT(t))
+	eRep := rep.RelayerExecReporter(t)
+
+	params, err := govv1.NewQueryClient(src.GetNode().GrpcConn).Params(ctx, &govv1.QueryParamsRequest{ParamsType: "voting"})
+	req.NoError(err)
+	if params.Params == nil || params.Params.VotingPeriod == nil || *params.Params.VotingPeriod > maxUpgradeVotingPeriod {
+		rep.TrackSkip(t, "skipping because the voting period of %s exceeds %s", src.Config().ChainID, maxUpgradeVotingPeriod)
+	}
+
+	ch, err := findChannel(ctx, r, eRep, src.Config().ChainID, "transfer")
+	req.NoError(err)
+
+	req.NoError(r.StartRelayer(ctx, eRep, pathName))
+	t.Cleanup(func() {
+		_ = r.StopRelayer(ctx, eRep)
+	})
+
+	authority, err := src.GetGovernanceAddress(ctx)
+	req.NoError(err)
+
+	msg := &channeltypes.MsgChannelUpgradeInit{
+		PortId:    ch.PortID,
+		ChannelId: ch.ChannelID,
+		Fields: channeltypes.UpgradeFields{
+			Ordering:       channeltypes.UNORDERED,
+			ConnectionHops: ch.ConnectionHops,
+			Version:        feeTransferVersion,
+		},
+		Signer: authority,
+	}
+
+	proposer := interchaintest.GetAndFundTestUsers(t, ctx, "upgrade", userFaucetFund, src)[0]
+	req.NoError(testutil.WaitForBlocks(ctx, 2, src))
+
+	prop, err := src.BuildProposal(
+		[]cosmos.ProtoMessage{msg},
+		"Upgrade "+ch.ChannelID, "Wrap "+ch.ChannelID+" in fee middleware", "",
+		sdk.Coins(params.Params.MinDeposit).String(), proposer.FormattedAddress(), false,
+	)
+	req.NoError(err)
+
+	height, err := src.Height(ctx)
+	req.NoError(err)
+
+	propTx, err := src.SubmitProposal(ctx, proposer.KeyName(), prop)
+	req.NoError(err, "failed to submit channel upgrade proposal")
+	propID, err := strconv.ParseUint(propTx.ProposalID, 10, 64)
+	req.NoError(err)
+
+	req.NoError(src.VoteOnProposalAllValidators(ctx, propID, cosmos.ProposalVoteYes))
+	_, err = cosmos.PollForProposalStatusV1(ctx, src, height, height+pollHeightMax, propID, govv1.StatusPassed)
+	req.NoError(err, "channel upgrade proposal did not pass")
+
+	// Both ends are open with the new version once the relayer completed the handshake.
+	for _, end := range []struct {
+		chain             *cosmos.CosmosChain
+		portID, channelID string
+	}{
+		{src, ch.PortID, ch.ChannelID},
+		{dst, ch.Counterparty.PortID, ch.Counterparty.ChannelID},
+	} {
+		chainID := end.chain.Config().ChainID
+		req.NoError(testutil.WaitForCondition(2*time.Minute, 5*time.Second, func() (bool, error) {
+			channels, err := r.GetChannels(ctx, eRep, chainID)
+			if err != nil {
+				return false, err
+			}
+			for _, c := range channels {
+				if c.PortID == end.portID && c.ChannelID == end.channelID {
+					return (c.State == "STATE_OPEN" || c.State == "Open") && sameVersion(c.Version, feeTransferVersion), nil
+				}
+			}
+			return false, nil
+		}), "channel %s on %s was not upgraded", end.channelID, chainID)
+	}
+}
+
+// sameVersion reports whether two channel versions are equal, comparing JSON encoded versions semantically.
+func sameVersion(a, b string) bool {
+	if a == b {
+		return true
+	}
+	var av, bv map[string]any
+	if json.Unmarshal([]byte(a), &av) != nil || json.Unmarshal([]byte(b), &bv) != nil {
+		return false
+	}
+	abz, _ := json.Marshal(av)
+	bbz, _ := json.Marshal(bv)
+	return string(abz) == string(bbz)
+}
@@ -6,6 +6,7 @@ import (
 	"io"
 	"net"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/docker/go-connections/nat"
 	dockerclient "github.com/moby/moby/client"
 	"github.com/moby/moby/errdefs"
+	"github.com/moby/moby/pkg/stdcopy"
 	"go.uber.org/zap"
 
 	"github.com/strangelove-ventures/interchaintest/v8/ibc"
@@ -194,6 +196,31 @@ func ParseSDKPanicFromText(text string) error {
 	return nil
 }
 
+// Logs returns the combined stdout and stderr of the container,
+// limited to the last tail lines unless tail is 0.
+func (c *ContainerLifecycle) Logs(ctx context.Context, tail uint64) (string, error) {
+	logOpts := container.LogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+	}
+	if tail != 0 {
+		logOpts.Tail = strconv.FormatUint(tail, 10)
+	}
+
+	rc, err := c.client.ContainerLogs(ctx, c.id, logOpts)
+	if err != nil {
+		return "", fmt.Errorf("failed to read logs from container %s: %w", c.containerName, err)
+	}
+	defer rc.Close()
+
+	// Logs are multiplexed into one stream; see docs for ContainerLogs.
+	logs := new(strings.Builder)
+	if _, err := stdcopy.StdCopy(logs, logs, rc); err != nil {
+		return "", fmt.Errorf("failed to read logs from container %s: %w", c.containerName, err)
+	}
+	return logs.String(), nil
+}
+
 func (c *ContainerLifecycle) PauseContainer(ctx context.Context) error {
 	return c.client.ContainerPause(ctx, c.id)
 }
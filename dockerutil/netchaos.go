@@ -0,0 +1,454 @@
+package dockerutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/moby/moby/client"
+	"go.uber.org/zap"
+
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+)
+
+// NetworkChaosImage is the image used to manipulate the network namespace of a target container.
+// It must ship with sh, ip, tc and iptables.
+var NetworkChaosImage = ibc.DockerImage{
+	Repository: "nicolaka/netshoot",
+	Version:    "v0.13",
+	UIDGID:     "0:0",
+}
+
+// chaosChain is the iptables chain that holds every partition rule,
+// so that healing a container is a single flush.
+const chaosChain = "ICT-CHAOS"
+
+// LinkFaults describes the degradation applied to outgoing traffic of a container.
+// Zero values are omitted from the resulting netem qdisc.
+type LinkFaults struct {
+	// Latency added to every packet.
+	Latency time.Duration
+
+	// Jitter is the random variation applied to Latency. Ignored if Latency is zero.
+	Jitter time.Duration
+
+	// Loss is the percentage (0-100) of packets to drop.
+	Loss float64
+
+	// Duplicate is the percentage (0-100) of packets to duplicate.
+	Duplicate float64
+
+	// Corrupt is the percentage (0-100) of packets to corrupt.
+	Corrupt float64
+}
+
+// netemArgs returns the netem arguments for the configured faults.
+func (f LinkFaults) netemArgs() (string, error) {
+	var args []string
+
+	if f.Latency < 0 || f.Jitter < 0 {
+		return "", fmt.Errorf("latency and jitter must not be negative")
+	}
+	if f.Latency > 0 {
+		args = append(args, "delay", fmt.Sprintf("%dms", f.Latency.Milliseconds()))
+		if f.Jitter > 0 {
+			args = append(args, fmt.Sprintf("%dms", f.Jitter.Milliseconds()))
+		}
+	}
+
+	for _, p := range []struct {
+		name  string
+		value float64
+	}{
+		{"loss", f.Loss},
+		{"duplicate", f.Duplicate},
+		{"corrupt", f.Corrupt},
+	} {
+		if p.value < 0 || p.value > 100 {
+			return "", fmt.Errorf("%s must be a percentage between 0 and 100, got %v", p.name, p.value)
+		}
+		if p.value > 0 {
+			args = append(args, p.name, fmt.Sprintf("%g%%", p.value))
+		}
+	}
+
+	if len(args) == 0 {
+		return "", fmt.Errorf("no link faults configured")
+	}
+
+	return strings.Join(args, " "), nil
+}
+
+// NetworkChaos injects network faults between containers attached to a test's Docker network,
+// such as the network returned by DockerSetup.
+//
+// Containers are referenced by name or ID, e.g. (*cosmos.ChainNode).Name() or (*relayer.DockerRelayer).ContainerID().
+// Faults are applied from a short-lived privileged container that joins the target's network namespace,
+// so the target images do not need iptables or tc installed.
+//
+// Any fault still active when the test finishes is healed by DockerCleanup if the containers are kept,
+// otherwise it disappears along with the containers.
+type NetworkChaos struct {
+	log       *zap.Logger
+	client    *client.Client
+	networkID string
+	testName  string
+
+	mu sync.Mutex
+	// Set of containers that have had any fault applied, and thus need healing.
+	faulted map[string]struct{}
+}
+
+var (
+	networkChaosMu sync.Mutex
+	// Map of test name to the NetworkChaos instances created for that test.
+	networkChaosByTest = map[string][]*NetworkChaos{}
+)
+
+// NewNetworkChaos returns a NetworkChaos scoped to the given network and test.
+//
+// "networkID" and "testName" are likely from DockerSetup and (*testing.T).Name().
+func NewNetworkChaos(log *zap.Logger, cli *client.Client, networkID, testName string) *NetworkChaos {
+	n := &NetworkChaos{
+		log:       log.With(zap.String("test_name", testName)),
+		client:    cli,
+		networkID: networkID,
+		testName:  testName,
+		faulted:   make(map[string]struct{}),
+	}
+
+	networkChaosMu.Lock()
+	defer networkChaosMu.Unlock()
+	networkChaosByTest[testName] = append(networkChaosByTest[testName], n)
+
+	return n
+}
+
+// Partition drops all traffic between every container in groupA and every container in groupB.
+// Traffic within a group is unaffected. Call Heal to restore connectivity.
+func (n *NetworkChaos) Partition(ctx context.Context, groupA, groupB []string) error {
+	if len(groupA) == 0 || len(groupB) == 0 {
+		return fmt.Errorf("both sides of a partition must contain at least one container")
+	}
+
+	ipsA, err := n.containerIPs(ctx, groupA)
+	if err != nil {
+		return err
+	}
+	ipsB, err := n.containerIPs(ctx, groupB)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range groupA {
+		if err := n.runInNetNS(ctx, c, partitionScript(ipsB)); err != nil {
+			return fmt.Errorf("partition %s: %w", c, err)
+		}
+	}
+	for _, c := range groupB {
+		if err := n.runInNetNS(ctx, c, partitionScript(ipsA)); err != nil {
+			return fmt.Errorf("partition %s: %w", c, err)
+		}
+	}
+
+	n.log.Info("Network partitioned", zap.Strings("group_a", groupA), zap.Strings("group_b", groupB))
+	return nil
+}
+
+// Isolate drops all traffic between the given containers and every other container on the network.
+func (n *NetworkChaos) Isolate(ctx context.Context, containers ...string) error {
+	others, err := n.otherContainers(ctx, containers)
+	if err != nil {
+		return err
+	}
+	if len(others) == 0 {
+		return nil
+	}
+	return n.Partition(ctx, containers, others)
+}
+
+// Degrade applies faults to traffic leaving each of the given containers.
+//
+// If peers is empty, all outgoing traffic is affected;
+// otherwise only traffic destined for the listed containers is.
+// Calling Degrade again on the same container replaces its previous faults.
+func (n *NetworkChaos) Degrade(ctx context.Context, containers []string, peers []string, faults LinkFaults) error {
+	netem, err := faults.netemArgs()
+	if err != nil {
+		return err
+	}
+
+	var peerIPs []string
+	if len(peers) > 0 {
+		peerIPs, err = n.containerIPs(ctx, peers)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, c := range containers {
+		ip, err := n.containerIP(ctx, c)
+		if err != nil {
+			return err
+		}
+		if err := n.runInNetNS(ctx, c, degradeScript(ip, netem, peerIPs)); err != nil {
+			return fmt.Errorf("degrade %s: %w", c, err)
+		}
+	}
+
+	n.log.Info("Network degraded",
+		zap.Strings("containers", containers),
+		zap.Strings("peers", peers),
+		zap.String("netem", netem),
+	)
+	return nil
+}
+
+// Heal removes every partition and degradation from the given containers.
+// If no containers are given, every container faulted through n is healed.
+func (n *NetworkChaos) Heal(ctx context.Context, containers ...string) error {
+	if len(containers) == 0 {
+		n.mu.Lock()
+		for c := range n.faulted {
+			containers = append(containers, c)
+		}
+		n.mu.Unlock()
+		sort.Strings(containers)
+	}
+
+	var errs []error
+	for _, c := range containers {
+		ip, err := n.containerIP(ctx, c)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := n.runInNetNS(ctx, c, healScript(ip)); err != nil {
+			errs = append(errs, fmt.Errorf("heal %s: %w", c, err))
+			continue
+		}
+
+		n.mu.Lock()
+		delete(n.faulted, c)
+		n.mu.Unlock()
+	}
+
+	n.log.Info("Network healed", zap.Strings("containers", containers))
+	return errors.Join(errs...)
+}
+
+// containerIP returns the IPv4 address of the container on n's network.
+func (n *NetworkChaos) containerIP(ctx context.Context, c string) (string, error) {
+	cjson, err := n.client.ContainerInspect(ctx, c)
+	if err != nil {
+		return "", fmt.Errorf("inspect container %s: %w", c, err)
+	}
+	if cjson.NetworkSettings == nil {
+		return "", fmt.Errorf("container %s has no network settings", c)
+	}
+	for _, es := range cjson.NetworkSettings.Networks {
+		if es != nil && es.NetworkID == n.networkID && es.IPAddress != "" {
+			return es.IPAddress, nil
+		}
+	}
+	return "", fmt.Errorf("container %s is not attached to network %s", c, n.networkID)
+}
+
+func (n *NetworkChaos) containerIPs(ctx context.Context, cs []string) ([]string, error) {
+	ips := make([]string, len(cs))
+	for i, c := range cs {
+		ip, err := n.containerIP(ctx, c)
+		if err != nil {
+			return nil, err
+		}
+		ips[i] = ip
+	}
+	return ips, nil
+}
+
+// otherContainers returns the IDs of the running containers of this test attached to n's network,
+// excluding the given containers.
+func (n *NetworkChaos) otherContainers(ctx context.Context, exclude []string) ([]string, error) {
+	nw, err := n.client.NetworkInspect(ctx, n.networkID, network.InspectOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("inspect network %s: %w", n.networkID, err)
+	}
+
+	skip := make(map[string]struct{}, len(exclude))
+	for _, c := range exclude {
+		cjson, err := n.client.ContainerInspect(ctx, c)
+		if err != nil {
+			return nil, fmt.Errorf("inspect container %s: %w", c, err)
+		}
+		skip[cjson.ID] = struct{}{}
+	}
+
+	var others []string
+	for id := range nw.Containers {
+		if _, ok := skip[id]; ok {
+			continue
+		}
+		cjson, err := n.client.ContainerInspect(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("inspect container %s: %w", id, err)
+		}
+		if cjson.Config == nil || cjson.Config.Labels[CleanupLabel] != n.testName {
+			continue
+		}
+		others = append(others, id)
+	}
+	sort.Strings(others)
+	return others, nil
+}
+
+// runInNetNS runs script in a one-off container sharing the network namespace of target.
+func (n *NetworkChaos) runInNetNS(ctx context.Context, target, script string) error {
+	if err := NetworkChaosImage.PullImage(ctx, n.client); err != nil {
+		return err
+	}
+
+	containerName := fmt.Sprintf("%s-netchaos-%d-%s", ICTDockerPrefix, time.Now().UnixNano(), RandLowerCaseLetterString(5))
+
+	cc, err := n.client.ContainerCreate(
+		ctx,
+		&container.Config{
+			Image: NetworkChaosImage.Ref(),
+
+			Entrypoint: []string{"sh", "-c"},
+			Cmd:        []string{script},
+
+			User: GetRootUserString(),
+
+			Labels: map[string]string{CleanupLabel: n.testName},
+		},
+		&container.HostConfig{
+			NetworkMode: container.NetworkMode("container:" + target),
+			CapAdd:      []string{"NET_ADMIN"},
+			AutoRemove:  false,
+		},
+		nil,
+		nil,
+		containerName,
+	)
+	if err != nil {
+		return fmt.Errorf("creating network chaos container: %w", err)
+	}
+
+	defer func() {
+		if err := n.client.ContainerRemove(ctx, cc.ID, container.RemoveOptions{
+			Force: true,
+		}); err != nil {
+			n.log.Warn("Failed to remove network chaos container", zap.String("container_id", cc.ID), zap.Error(err))
+		}
+	}()
+
+	if err := n.client.ContainerStart(ctx, cc.ID, container.StartOptions{}); err != nil {
+		return fmt.Errorf("starting network chaos container: %w", err)
+	}
+
+	n.mu.Lock()
+	n.faulted[target] = struct{}{}
+	n.mu.Unlock()
+
+	waitCh, errCh := n.client.ContainerWait(ctx, cc.ID, container.WaitConditionNotRunning)
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-errCh:
+		return err
+	case res := <-waitCh:
+		if res.Error != nil {
+			return fmt.Errorf("waiting for network chaos container: %s", res.Error.Message)
+		}
+		if res.StatusCode != 0 {
+			return fmt.Errorf("network chaos script exited %d", res.StatusCode)
+		}
+	}
+
+	return nil
+}
+
+// partitionScript drops all traffic to and from the given IPs.
+func partitionScript(ips []string) string {
+	lines := []string{
+		"set -e",
+		fmt.Sprintf("iptables -N %s 2>/dev/null || true", chaosChain),
+		fmt.Sprintf("iptables -C INPUT -j %[1]s 2>/dev/null || iptables -I INPUT -j %[1]s", chaosChain),
+		fmt.Sprintf("iptables -C OUTPUT -j %[1]s 2>/dev/null || iptables -I OUTPUT -j %[1]s", chaosChain),
+	}
+	for _, ip := range ips {
+		lines = append(lines,
+			fmt.Sprintf("iptables -A %s -s %s -j DROP", chaosChain, ip),
+			fmt.Sprintf("iptables -A %s -d %s -j DROP", chaosChain, ip),
+		)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// deviceLookup resolves the interface holding ip into $DEV.
+func deviceLookup(ip string) string {
+	return fmt.Sprintf(`DEV=$(ip -o -4 addr show | awk '$4 ~ /^%s\// {print $2}')`, strings.ReplaceAll(ip, ".", `\.`))
+}
+
+// degradeScript replaces the root qdisc of the interface holding ip with netem,
+// optionally only for traffic to peerIPs.
+func degradeScript(ip, netem string, peerIPs []string) string {
+	lines := []string{
+		"set -e",
+		deviceLookup(ip),
+		`tc qdisc del dev "$DEV" root 2>/dev/null || true`,
+	}
+
+	if len(peerIPs) == 0 {
+		lines = append(lines, fmt.Sprintf(`tc qdisc add dev "$DEV" root netem %s`, netem))
+		return strings.Join(lines, "\n")
+	}
+
+	// The default priomap only uses the first three bands,
+	// so the fourth band only receives traffic matched by the filters below.
+	lines = append(lines,
+		`tc qdisc add dev "$DEV" root handle 1: prio bands 4`,
+		fmt.Sprintf(`tc qdisc add dev "$DEV" parent 1:4 handle 40: netem %s`, netem),
+	)
+	for _, peer := range peerIPs {
+		lines = append(lines, fmt.Sprintf(`tc filter add dev "$DEV" parent 1:0 protocol ip prio 1 u32 match ip dst %s/32 flowid 1:4`, peer))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// healScript removes every fault applied by partitionScript and degradeScript.
+func healScript(ip string) string {
+	return strings.Join([]string{
+		deviceLookup(ip),
+		`tc qdisc del dev "$DEV" root 2>/dev/null || true`,
+		fmt.Sprintf("iptables -F %s 2>/dev/null || true", chaosChain),
+	}, "\n")
+}
+
+// healNetworkChaos heals every fault applied during the named test.
+func healNetworkChaos(ctx context.Context, t DockerSetupTestingT) {
+	networkChaosMu.Lock()
+	chaos := networkChaosByTest[t.Name()]
+	delete(networkChaosByTest, t.Name())
+	networkChaosMu.Unlock()
+
+	for _, n := range chaos {
+		if err := n.Heal(ctx); err != nil {
+			t.Logf("Failed to heal network chaos during docker cleanup: %v", err)
+		}
+	}
+}
+
+// forgetNetworkChaos drops the record of faults applied during the named test,
+// for when the faulted containers are about to be removed anyway.
+func forgetNetworkChaos(t DockerSetupTestingT) {
+	networkChaosMu.Lock()
+	defer networkChaosMu.Unlock()
+	delete(networkChaosByTest, t.Name())
+}
@@ -0,0 +1,51 @@
+package dockerutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinkFaultsNetemArgs(t *testing.T) {
+	for _, tt := range []struct {
+		Faults  LinkFaults
+		Want    string
+		WantErr bool
+	}{
+		{LinkFaults{Latency: 200 * time.Millisecond}, "delay 200ms", false},
+		{LinkFaults{Latency: time.Second, Jitter: 50 * time.Millisecond}, "delay 1000ms 50ms", false},
+		{LinkFaults{Jitter: 50 * time.Millisecond, Loss: 10}, "loss 10%", false},
+		{LinkFaults{Latency: 10 * time.Millisecond, Loss: 2.5, Duplicate: 1, Corrupt: 0.1}, "delay 10ms loss 2.5% duplicate 1% corrupt 0.1%", false},
+		{LinkFaults{}, "", true},
+		{LinkFaults{Loss: 101}, "", true},
+		{LinkFaults{Latency: -time.Second}, "", true},
+	} {
+		got, err := tt.Faults.netemArgs()
+		if tt.WantErr {
+			require.Error(t, err, tt)
+			continue
+		}
+		require.NoError(t, err, tt)
+		require.Equal(t, tt.Want, got, tt)
+	}
+}
+
+func TestNetworkChaosScripts(t *testing.T) {
+	partition := partitionScript([]string{"172.18.0.2", "172.18.0.3"})
+	require.Contains(t, partition, "iptables -A ICT-CHAOS -s 172.18.0.2 -j DROP")
+	require.Contains(t, partition, "iptables -A ICT-CHAOS -d 172.18.0.3 -j DROP")
+
+	all := degradeScript("172.18.0.4", "delay 100ms", nil)
+	require.Contains(t, all, `awk '$4 ~ /^172\.18\.0\.4\// {print $2}'`)
+	require.Contains(t, all, `tc qdisc add dev "$DEV" root netem delay 100ms`)
+	require.NotContains(t, all, "tc filter")
+
+	targeted := degradeScript("172.18.0.4", "loss 5%", []string{"172.18.0.5"})
+	require.Contains(t, targeted, `tc qdisc add dev "$DEV" parent 1:4 handle 40: netem loss 5%`)
+	require.Contains(t, targeted, "match ip dst 172.18.0.5/32 flowid 1:4")
+
+	heal := healScript("172.18.0.4")
+	require.Contains(t, heal, "iptables -F ICT-CHAOS")
+	require.Contains(t, heal, `tc qdisc del dev "$DEV" root`)
+}
@@ -187,6 +187,14 @@ func DockerCleanup(t DockerSetupTestingT, cli *client.Client) func() {
 
 		ctx := context.TODO()
 		cli.NegotiateAPIVersion(ctx)
+
+		if keepContainers {
+			// Kept containers should be usable for debugging, so undo any injected network faults.
+			healNetworkChaos(ctx, t)
+		} else {
+			forgetNetworkChaos(t)
+		}
+
 		cs, err := cli.ContainerList(ctx, container.ListOptions{
 			All: true,
 			Filters: filters.NewArgs(
@@ -0,0 +1,240 @@
+package dockerutil
+
+import (
+	"archive/tar"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/moby/moby/client"
+	"go.uber.org/zap"
+)
+
+// RewriteFunc optionally transforms the content of a file while it is imported into a volume.
+// relPath is relative to the root of the volume. Returning content unmodified leaves the file as is.
+type RewriteFunc func(relPath string, content []byte) []byte
+
+// VolumeArchiver copies the entire contents of a Docker volume to and from a tar stream,
+// e.g. to snapshot a node home directory and restore it into a volume created by a later test.
+type VolumeArchiver struct {
+	log *zap.Logger
+
+	cli *client.Client
+
+	testName string
+}
+
+// NewVolumeArchiver returns a new VolumeArchiver.
+func NewVolumeArchiver(log *zap.Logger, cli *client.Client, testName string) *VolumeArchiver {
+	return &VolumeArchiver{log: log, cli: cli, testName: testName}
+}
+
+// Export writes every file in the given volume to w as a tar stream.
+// Entry names are relative to the root of the volume.
+//
+// The volume should not be in use by a running container, or the archive may be inconsistent.
+func (a *VolumeArchiver) Export(ctx context.Context, volumeName string, w io.Writer) error {
+	const mountPath = "/mnt/dockervolume"
+
+	if err := EnsureBusybox(ctx, a.cli); err != nil {
+		return err
+	}
+
+	containerName := fmt.Sprintf("%s-exportvolume-%d-%s", ICTDockerPrefix, time.Now().UnixNano(), RandLowerCaseLetterString(5))
+
+	cc, err := a.cli.ContainerCreate(
+		ctx,
+		&container.Config{
+			Image: busyboxRef,
+
+			// Use root user to avoid permission issues when reading files from the volume.
+			User: GetRootUserString(),
+
+			Labels: map[string]string{CleanupLabel: a.testName},
+		},
+		&container.HostConfig{
+			Binds:      []string{volumeName + ":" + mountPath},
+			AutoRemove: true,
+		},
+		nil, // No networking necessary.
+		nil,
+		containerName,
+	)
+	if err != nil {
+		return fmt.Errorf("creating container: %w", err)
+	}
+
+	defer func() {
+		if err := a.cli.ContainerRemove(ctx, cc.ID, container.RemoveOptions{
+			Force: true,
+		}); err != nil {
+			a.log.Warn("Failed to remove export volume container", zap.String("container_id", cc.ID), zap.Error(err))
+		}
+	}()
+
+	rc, _, err := a.cli.CopyFromContainer(ctx, cc.ID, mountPath)
+	if err != nil {
+		return fmt.Errorf("copying from container: %w", err)
+	}
+	defer func() {
+		_ = rc.Close()
+	}()
+
+	// The archive is rooted at the base name of the mount path; strip that prefix.
+	prefix := path.Base(mountPath) + "/"
+	return rewriteTar(tar.NewReader(rc), tar.NewWriter(w), func(hdr *tar.Header) bool {
+		if !strings.HasPrefix(hdr.Name, prefix) {
+			return false
+		}
+		hdr.Name = strings.TrimPrefix(hdr.Name, prefix)
+		return hdr.Name != ""
+	}, nil)
+}
+
+// Import extracts the tar stream r, as produced by Export, into the given volume.
+// Existing files with the same names are overwritten.
+// Every imported file is owned by the owner of the volume root, as set by SetVolumeOwner.
+//
+// If rewrite is non-nil, it is applied to the content of every regular file.
+func (a *VolumeArchiver) Import(ctx context.Context, volumeName string, r io.Reader, rewrite RewriteFunc) error {
+	const mountPath = "/mnt/dockervolume"
+
+	if err := EnsureBusybox(ctx, a.cli); err != nil {
+		return err
+	}
+
+	containerName := fmt.Sprintf("%s-importvolume-%d-%s", ICTDockerPrefix, time.Now().UnixNano(), RandLowerCaseLetterString(5))
+
+	cc, err := a.cli.ContainerCreate(
+		ctx,
+		&container.Config{
+			Image: busyboxRef,
+
+			Entrypoint: []string{"sh", "-c"},
+			Cmd: []string{
+				// Take the uid and gid of the mount path,
+				// and set that as the owner of everything imported.
+				`chown -R "$(stat -c '%u:%g' "$1")" "$1"`,
+				"_", // Meaningless arg0 for sh -c with positional args.
+				mountPath,
+			},
+
+			// Use root user to avoid permission issues when writing files to the volume.
+			User: GetRootUserString(),
+
+			Labels: map[string]string{CleanupLabel: a.testName},
+		},
+		&container.HostConfig{
+			Binds:      []string{volumeName + ":" + mountPath},
+			AutoRemove: true,
+		},
+		nil, // No networking necessary.
+		nil,
+		containerName,
+	)
+	if err != nil {
+		return fmt.Errorf("creating container: %w", err)
+	}
+
+	autoRemoved := false
+	defer func() {
+		if autoRemoved {
+			// No need to attempt removing the container if we successfully started and waited for it to complete.
+			return
+		}
+
+		if err := a.cli.ContainerRemove(ctx, cc.ID, container.RemoveOptions{
+			Force: true,
+		}); err != nil {
+			a.log.Warn("Failed to remove import volume container", zap.String("container_id", cc.ID), zap.Error(err))
+		}
+	}()
+
+	pr, pw := io.Pipe()
+	go func() {
+		_ = pw.CloseWithError(rewriteTar(tar.NewReader(r), tar.NewWriter(pw), nil, rewrite))
+	}()
+
+	if err := a.cli.CopyToContainer(
+		ctx,
+		cc.ID,
+		mountPath,
+		pr,
+		container.CopyToContainerOptions{},
+	); err != nil {
+		_ = pr.CloseWithError(err)
+		return fmt.Errorf("copying tar to container: %w", err)
+	}
+
+	if err := a.cli.ContainerStart(ctx, cc.ID, container.StartOptions{}); err != nil {
+		return fmt.Errorf("starting import volume container: %w", err)
+	}
+
+	waitCh, errCh := a.cli.ContainerWait(ctx, cc.ID, container.WaitConditionNotRunning)
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-errCh:
+		return err
+	case res := <-waitCh:
+		autoRemoved = true
+
+		if res.Error != nil {
+			return fmt.Errorf("waiting for import volume container: %s", res.Error.Message)
+		}
+
+		if res.StatusCode != 0 {
+			return fmt.Errorf("chown on imported volume exited %d", res.StatusCode)
+		}
+	}
+
+	return nil
+}
+
+// rewriteTar copies every entry from tr to tw.
+// If keep is non-nil, it may modify each header and reports whether the entry is copied.
+// If rewrite is non-nil, it is applied to the content of every regular file.
+func rewriteTar(tr *tar.Reader, tw *tar.Writer, keep func(*tar.Header) bool, rewrite RewriteFunc) error {
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("reading tar: %w", err)
+		}
+
+		if keep != nil && !keep(hdr) {
+			continue
+		}
+
+		if hdr.Typeflag != tar.TypeReg || rewrite == nil {
+			if err := tw.WriteHeader(hdr); err != nil {
+				return fmt.Errorf("writing tar header: %w", err)
+			}
+			if _, err := io.Copy(tw, tr); err != nil {
+				return fmt.Errorf("writing tar content: %w", err)
+			}
+			continue
+		}
+
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return fmt.Errorf("reading tar content: %w", err)
+		}
+		content = rewrite(hdr.Name, content)
+		hdr.Size = int64(len(content))
+		if err := tw.WriteHeader(hdr); err != nil {
+			return fmt.Errorf("writing tar header: %w", err)
+		}
+		if _, err := tw.Write(content); err != nil {
+			return fmt.Errorf("writing tar content: %w", err)
+		}
+	}
+
+	return tw.Close()
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"strconv"
 	"testing"
 	"time"
 
 	sdkmath "cosmossdk.io/math"
+	circuittypes "cosmossdk.io/x/circuit/types"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/strangelove-ventures/interchaintest/v8"
@@ -21,6 +24,7 @@ import (
 	vestingcli "github.com/cosmos/cosmos-sdk/x/auth/vesting/client/cli"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	"github.com/cosmos/cosmos-sdk/x/group"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 )
 
@@ -139,6 +143,21 @@ func TestCoreSDKCommands(t *testing.T) {
 		testGov(ctx, t, chain, users)
 	})
 
+	t.Run("circuit", func(t *testing.T) {
+		users := interchaintest.GetAndFundTestUsers(t, ctx, "default", genesisAmt, chain, chain)
+		testCircuit(ctx, t, chain, users)
+	})
+
+	t.Run("consensus", func(t *testing.T) {
+		users := interchaintest.GetAndFundTestUsers(t, ctx, "default", genesisAmt, chain)
+		testConsensus(ctx, t, chain, users)
+	})
+
+	t.Run("group", func(t *testing.T) {
+		users := interchaintest.GetAndFundTestUsers(t, ctx, "default", genesisAmt, chain, chain, chain)
+		testGroup(ctx, t, chain, users)
+	})
+
 	t.Run("auth-vesting", func(t *testing.T) {
 		testAuth(ctx, t, chain)
 		testVesting(ctx, t, chain, superAdmin)
@@ -573,6 +592,164 @@ func testGov(ctx context.Context, t *testing.T, chain *cosmos.CosmosChain, users
 	require.Len(t, proposals, 1)
 }
 
+// passProposal submits a governance proposal executing msgs, and waits for all validators to pass it.
+func passProposal(ctx context.Context, t *testing.T, chain *cosmos.CosmosChain, proposer ibc.Wallet, msgs ...cosmos.ProtoMessage) {
+	govModule, err := chain.AuthQueryModuleAddress(ctx, "gov")
+	require.NoError(t, err)
+
+	prop, err := chain.BuildProposal(msgs, "Test Proposal", "Test Proposal Summary", "none", "500"+chain.Config().Denom, govModule, false)
+	require.NoError(t, err)
+
+	tx, err := chain.SubmitProposal(ctx, proposer.KeyName(), prop)
+	require.NoError(t, err)
+
+	proposalID, err := strconv.ParseUint(tx.ProposalID, 10, 64)
+	require.NoError(t, err)
+
+	require.NoError(t, chain.VoteOnProposalAllValidators(ctx, proposalID, cosmos.ProposalVoteYes))
+
+	height, err := chain.Height(ctx)
+	require.NoError(t, err)
+
+	_, err = cosmos.PollForProposalStatusV1(ctx, chain, height, height+15, proposalID, govv1.ProposalStatus_PROPOSAL_STATUS_PASSED)
+	require.NoError(t, err)
+}
+
+func testCircuit(ctx context.Context, t *testing.T, chain *cosmos.CosmosChain, users []ibc.Wallet) {
+	node := chain.GetNode()
+	sendTypeURL := sdk.MsgTypeURL(&banktypes.MsgSend{})
+
+	// trip the circuit breaker of MsgSend and make users[0] a super admin through governance
+	disableMsg, err := chain.CircuitDisableMsg(ctx, "", sendTypeURL)
+	require.NoError(t, err)
+	authorizeMsg, err := chain.CircuitAuthorizeMsg(ctx, "", users[0].FormattedAddress(), circuittypes.Permissions_LEVEL_SUPER_ADMIN)
+	require.NoError(t, err)
+
+	passProposal(ctx, t, chain, users[0], disableMsg, authorizeMsg)
+
+	disabled, err := chain.CircuitQueryDisabledList(ctx)
+	require.NoError(t, err)
+	require.Contains(t, disabled, sendTypeURL)
+
+	perms, err := chain.CircuitQueryAccount(ctx, users[0].FormattedAddress())
+	require.NoError(t, err)
+	require.Equal(t, circuittypes.Permissions_LEVEL_SUPER_ADMIN, perms.Level)
+
+	accounts, err := chain.CircuitQueryAccounts(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, accounts)
+
+	// bank sends are rejected while the circuit breaker is tripped
+	coins := ibc.WalletAmount{Address: users[1].FormattedAddress(), Denom: chain.Config().Denom, Amount: sdkmath.NewInt(1)}
+	require.Error(t, node.BankSend(ctx, users[0].KeyName(), coins))
+
+	// users[0] can grant permissions, and reset the circuit breaker
+	require.NoError(t, node.CircuitAuthorize(ctx, users[0].KeyName(), users[1].FormattedAddress(), circuittypes.Permissions_LEVEL_SOME_MSGS, []string{sendTypeURL}))
+
+	perms, err = chain.CircuitQueryAccount(ctx, users[1].FormattedAddress())
+	require.NoError(t, err)
+	require.Equal(t, []string{sendTypeURL}, perms.LimitTypeUrls)
+
+	require.NoError(t, node.CircuitReset(ctx, users[1].KeyName(), sendTypeURL))
+
+	disabled, err = chain.CircuitQueryDisabledList(ctx)
+	require.NoError(t, err)
+	require.NotContains(t, disabled, sendTypeURL)
+
+	require.NoError(t, node.BankSend(ctx, users[0].KeyName(), coins))
+}
+
+func testConsensus(ctx context.Context, t *testing.T, chain *cosmos.CosmosChain, users []ibc.Wallet) {
+	params, err := chain.ConsensusQueryParams(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, params.Block)
+
+	maxGas := int64(100_000_000)
+	require.NotEqual(t, maxGas, params.Block.MaxGas)
+
+	msg, err := chain.ConsensusUpdateParamsMsg(ctx, "", func(p *cmtproto.ConsensusParams) {
+		p.Block.MaxGas = maxGas
+	})
+	require.NoError(t, err)
+
+	passProposal(ctx, t, chain, users[0], msg)
+
+	params, err = chain.ConsensusQueryParams(ctx)
+	require.NoError(t, err)
+	require.Equal(t, maxGas, params.Block.MaxGas)
+}
+
+func testGroup(ctx context.Context, t *testing.T, chain *cosmos.CosmosChain, users []ibc.Wallet) {
+	node := chain.GetNode()
+	admin, member := users[0], users[1]
+
+	members := []group.MemberRequest{
+		{Address: admin.FormattedAddress(), Weight: "1"},
+		{Address: member.FormattedAddress(), Weight: "1"},
+	}
+	policy := group.NewThresholdDecisionPolicy("2", 20*time.Second, 0)
+
+	groupID, policyAddr, err := node.GroupCreateWithPolicy(ctx, admin.KeyName(), "group", "policy", members, policy, false)
+	require.NoError(t, err)
+
+	groups, err := chain.GroupQueryGroupsByMember(ctx, member.FormattedAddress())
+	require.NoError(t, err)
+	require.Len(t, groups, 1)
+	require.Equal(t, groupID, groups[0].Id)
+
+	groupMembers, err := chain.GroupQueryGroupMembers(ctx, groupID)
+	require.NoError(t, err)
+	require.Len(t, groupMembers, 2)
+
+	policies, err := chain.GroupQueryGroupPoliciesByGroup(ctx, groupID)
+	require.NoError(t, err)
+	require.Len(t, policies, 1)
+	require.Equal(t, policyAddr, policies[0].Address)
+
+	// fund the policy so that the proposal can be executed
+	coin := sdk.NewCoin(chain.Config().Denom, sdkmath.NewInt(1_000))
+	require.NoError(t, node.BankSend(ctx, admin.KeyName(), ibc.WalletAmount{
+		Address: policyAddr,
+		Denom:   coin.Denom,
+		Amount:  coin.Amount,
+	}))
+
+	bankMsg := &banktypes.MsgSend{
+		FromAddress: policyAddr,
+		ToAddress:   users[2].FormattedAddress(),
+		Amount:      sdk.NewCoins(coin),
+	}
+	prop, err := chain.BuildGroupProposal(policyAddr, []cosmos.ProtoMessage{bankMsg}, "Group Proposal", "Group Proposal Summary", "")
+	require.NoError(t, err)
+
+	proposalID, err := node.GroupSubmitProposal(ctx, admin.KeyName(), prop, false)
+	require.NoError(t, err)
+
+	require.NoError(t, node.GroupVote(ctx, admin.KeyName(), proposalID, group.VOTE_OPTION_YES, "", false))
+	require.NoError(t, node.GroupVote(ctx, member.KeyName(), proposalID, group.VOTE_OPTION_YES, "", false))
+
+	votes, err := chain.GroupQueryVotesByProposal(ctx, proposalID)
+	require.NoError(t, err)
+	require.Len(t, votes, 2)
+
+	tally, err := chain.GroupQueryTally(ctx, proposalID)
+	require.NoError(t, err)
+	require.Equal(t, "2", tally.YesCount)
+
+	// wait for the voting period to end
+	require.NoError(t, testutil.WaitForBlocks(ctx, 12, chain))
+
+	proposal, err := chain.GroupQueryProposal(ctx, proposalID)
+	require.NoError(t, err)
+	require.Equal(t, group.PROPOSAL_STATUS_ACCEPTED, proposal.Status)
+
+	require.NoError(t, node.GroupExec(ctx, member.KeyName(), proposalID))
+
+	bal, err := chain.BankQueryBalance(ctx, users[2].FormattedAddress(), coin.Denom)
+	require.NoError(t, err)
+	require.Equal(t, genesisAmt.Add(coin.Amount), bal)
+}
+
 func testSlashing(ctx context.Context, t *testing.T, chain *cosmos.CosmosChain) {
 	p, err := chain.SlashingQueryParams(ctx)
 	require.NoError(t, err)
@@ -0,0 +1,61 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+
+	"cosmossdk.io/math"
+	"github.com/strangelove-ventures/interchaintest/v8"
+	"github.com/strangelove-ventures/interchaintest/v8/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGaiaFork(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	chains := interchaintest.CreateChainWithConfig(t, 2, numFullNodesZero, "gaia", "v17.3.0", ibc.ChainConfig{})
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	enableBlockDB := false
+	ctx, _, _, _ := interchaintest.BuildInitialChain(t, chains, enableBlockDB)
+
+	users := interchaintest.GetAndFundTestUsers(t, ctx, "default", math.NewInt(10_000_000), chain)
+	user := users[0]
+	balance, err := chain.GetBalance(ctx, user.FormattedAddress(), chain.Config().Denom)
+	require.NoError(t, err)
+
+	// Export the state of the chain, standing in for an exported mainnet state.
+	height, err := chain.Height(ctx)
+	require.NoError(t, err)
+	require.NoError(t, chain.StopAllNodes(ctx))
+	genesis, err := chain.ForkGenesis(ctx, height)
+	require.NoError(t, err)
+
+	forks := interchaintest.CreateChainWithConfig(t, 2, numFullNodesZero, "gaia", "v17.3.0", ibc.ChainConfig{
+		ChainID: "fork-1",
+		Genesis: genesis,
+	})
+	fork := forks[0].(*cosmos.CosmosChain)
+	_, _, _, _ = interchaintest.BuildInitialChain(t, forks, enableBlockDB)
+
+	forkHeight, err := fork.Height(context.Background())
+	require.NoError(t, err)
+	require.Greater(t, forkHeight, height)
+
+	// The state carries over, and the validators of the fork took over the validators of the chain.
+	forkBalance, err := fork.GetBalance(ctx, user.FormattedAddress(), fork.Config().Denom)
+	require.NoError(t, err)
+	require.Equal(t, balance, forkBalance)
+
+	valoper, err := fork.Validators[0].KeyBech32(ctx, "validator", "val")
+	require.NoError(t, err)
+	val, err := fork.StakingQueryValidator(ctx, valoper)
+	require.NoError(t, err)
+	require.False(t, val.Jailed)
+
+	forkUsers := interchaintest.GetAndFundTestUsers(t, ctx, "default", math.NewInt(10_000_000), fork)
+	require.Len(t, forkUsers, 1)
+}
@@ -0,0 +1,87 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/strangelove-ventures/interchaintest/v8"
+	"github.com/strangelove-ventures/interchaintest/v8/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// TestGRPCTxMode runs the bank, staking and gov helpers on a chain that creates keys and signs
+// transactions in Go and broadcasts them over gRPC, rather than with the tx subcommand of the binary.
+func TestGRPCTxMode(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+	t.Parallel()
+
+	cosmos.SetSDKConfig(baseBech32)
+
+	sdk47Genesis := []cosmos.GenesisKV{
+		cosmos.NewGenesisKV("app_state.gov.params.voting_period", "15s"),
+		cosmos.NewGenesisKV("app_state.gov.params.max_deposit_period", "10s"),
+		cosmos.NewGenesisKV("app_state.gov.params.min_deposit.0.denom", "token"),
+		cosmos.NewGenesisKV("app_state.gov.params.min_deposit.0.amount", "1"),
+		cosmos.NewGenesisKV("app_state.bank.denom_metadata", []banktypes.Metadata{denomMetadata}),
+	}
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			Name:      "ibc-go-simd",
+			ChainName: "ibc-go-simd",
+			Version:   "v8.0.0", // SDK v50
+			ChainConfig: ibc.ChainConfig{
+				Denom:         denomMetadata.Base,
+				Bech32Prefix:  baseBech32,
+				CoinType:      "118",
+				ModifyGenesis: cosmos.ModifyGenesis(sdk47Genesis),
+				GasAdjustment: 1.5,
+				TxMode:        ibc.TxModeGRPC,
+			},
+			NumValidators: &numValsOne,
+			NumFullNodes:  &numFullNodesZero,
+		},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	chain := chains[0].(*cosmos.CosmosChain)
+	require.True(t, chain.GetNode().UsesGRPCTx())
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chain)
+
+	ctx := context.Background()
+	client, network := interchaintest.DockerSetup(t)
+
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:         t.Name(),
+		Client:           client,
+		NetworkID:        network,
+		SkipPathCreation: true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	t.Run("bank", func(t *testing.T) {
+		users := interchaintest.GetAndFundTestUsers(t, ctx, "default", genesisAmt, chain, chain, chain)
+		testBank(ctx, t, chain, users)
+	})
+
+	t.Run("staking", func(t *testing.T) {
+		users := interchaintest.GetAndFundTestUsers(t, ctx, "default", genesisAmt, chain, chain, chain)
+		testStaking(ctx, t, chain, users)
+	})
+
+	t.Run("gov", func(t *testing.T) {
+		users := interchaintest.GetAndFundTestUsers(t, ctx, "default", genesisAmt, chain, chain, chain)
+		testGov(ctx, t, chain, users)
+	})
+}
@@ -0,0 +1,54 @@
+package cosmos_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v8"
+	"github.com/strangelove-ventures/interchaintest/v8/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGaiaRollingUpgrade(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	// No validator holds more than a third of the voting power, so the chain keeps producing blocks
+	// while any one of them restarts.
+	chains := interchaintest.CreateChainWithConfig(t, 4, numFullNodesZero, "gaia", "v17.2.0", ibc.ChainConfig{})
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	enableBlockDB := false
+	ctx, _, _, _ := interchaintest.BuildInitialChain(t, chains, enableBlockDB)
+
+	image := ibc.DockerImage{
+		Repository: "ghcr.io/strangelove-ventures/heighliner/gaia",
+		Version:    "v17.3.0",
+		UIDGID:     chain.Config().Images[0].UIDGID,
+	}
+
+	// Upgrade half of the validators, the chain must keep running mixed versions.
+	res, err := chain.RollingUpgrade(ctx, image, cosmos.RollingUpgradeOptions{
+		Nodes:         chain.Validators[:2],
+		BlocksBetween: 2,
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Nodes, 2)
+	require.False(t, res.LivenessLost, "chain halted while a validator restarted")
+	for _, n := range res.Nodes {
+		t.Logf("Upgraded %s from %s in %s, caught up in %s, longest block interval %s",
+			n.Node.Name(), n.PreviousImage.Version, n.Restart, n.CatchUp, n.MaxBlockInterval)
+	}
+	require.Equal(t, "v17.2.0", chain.Config().Images[0].Version, "chain image changes once all nodes upgraded")
+
+	// Upgrade the rest.
+	res, err = chain.RollingUpgrade(ctx, image, cosmos.RollingUpgradeOptions{
+		Nodes:        chain.Validators[2:],
+		StallTimeout: 20 * time.Second,
+	})
+	require.NoError(t, err)
+	require.False(t, res.LivenessLost, "chain halted while a validator restarted")
+	require.Equal(t, image.Version, chain.Config().Images[0].Version)
+}
@@ -0,0 +1,48 @@
+package cosmos_test
+
+import (
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v8"
+	"github.com/strangelove-ventures/interchaintest/v8/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGaiaSlashing(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	// Jail validators quickly for downtime.
+	slashingGenesis := []cosmos.GenesisKV{
+		cosmos.NewGenesisKV("app_state.slashing.params.signed_blocks_window", "10"),
+		cosmos.NewGenesisKV("app_state.slashing.params.min_signed_per_window", "0.500000000000000000"),
+		cosmos.NewGenesisKV("app_state.slashing.params.downtime_jail_duration", "10s"),
+	}
+
+	// No validator holds more than a third of the voting power, so the chain keeps producing blocks
+	// while one of them is down.
+	chains := interchaintest.CreateChainWithConfig(t, 4, numFullNodesZero, "gaia", "v17.3.0", ibc.ChainConfig{
+		ModifyGenesis: cosmos.ModifyGenesis(slashingGenesis),
+	})
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	enableBlockDB := false
+	ctx, _, _, _ := interchaintest.BuildInitialChain(t, chains, enableBlockDB)
+
+	t.Run("downtime", func(t *testing.T) {
+		state, err := chain.Downtime(ctx, chain.Validators[3], 0)
+		require.NoError(t, err)
+		require.True(t, state.Validator.Jailed)
+		require.False(t, state.SigningInfo.Tombstoned)
+		require.NotZero(t, state.SigningInfo.JailedUntil)
+	})
+
+	t.Run("double sign", func(t *testing.T) {
+		_, state, err := chain.DoubleSign(ctx, chain.Validators[2], 20)
+		require.NoError(t, err)
+		require.True(t, state.Validator.Jailed)
+		require.True(t, state.SigningInfo.Tombstoned)
+	})
+}
@@ -0,0 +1,48 @@
+package cosmos_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/strangelove-ventures/interchaintest/v8"
+	"github.com/strangelove-ventures/interchaintest/v8/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/strangelove-ventures/interchaintest/v8/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGaiaStateSync(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	const snapshotInterval = 10
+
+	chains := interchaintest.CreateChainWithConfig(t, numValsOne, numFullNodesZero, "gaia", "v17.3.0", ibc.ChainConfig{
+		ConfigFileOverrides: cosmos.StateSyncSnapshotOverrides(snapshotInterval, 2),
+	})
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	enableBlockDB := false
+	ctx, _, _, _ := interchaintest.BuildInitialChain(t, chains, enableBlockDB)
+
+	// Wait for a snapshot to be taken.
+	timeoutCtx, timeoutCtxCancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer timeoutCtxCancel()
+	require.NoError(t, testutil.WaitForBlocks(timeoutCtx, snapshotInterval+2, chain))
+
+	node, err := chain.AddStateSyncFullNode(ctx, nil, cosmos.StateSyncOptions{})
+	require.NoError(t, err)
+
+	// The node has no blocks before the snapshot it restored.
+	status, err := node.Client.Status(ctx)
+	require.NoError(t, err)
+	require.Greater(t, status.SyncInfo.EarliestBlockHeight, int64(1), "node synced from genesis")
+
+	// The node keeps up with the chain.
+	timeoutCtx, timeoutCtxCancel = context.WithTimeout(ctx, time.Minute)
+	defer timeoutCtxCancel()
+	require.NoError(t, testutil.WaitForBlocks(timeoutCtx, 3, node))
+	require.NoError(t, testutil.WaitForInSync(timeoutCtx, chain, node))
+}
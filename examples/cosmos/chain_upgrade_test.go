@@ -0,0 +1,63 @@
+package cosmos_test
+
+import (
+	"testing"
+	"time"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v8"
+	"github.com/strangelove-ventures/interchaintest/v8/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGaiaUpgrade upgrades a chain in place with cosmos.CosmosChain.Upgrade,
+// which proposes the upgrade, waits for the halt, swaps the version of the nodes and waits for new blocks.
+func TestGaiaUpgrade(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	t.Parallel()
+
+	shortVoteGenesis := []cosmos.GenesisKV{
+		cosmos.NewGenesisKV("app_state.gov.params.voting_period", votingPeriod),
+		cosmos.NewGenesisKV("app_state.gov.params.max_deposit_period", maxDepositPeriod),
+		cosmos.NewGenesisKV("app_state.gov.params.min_deposit.0.denom", "uatom"),
+	}
+
+	chains := interchaintest.CreateChainWithConfig(t, numValsOne, numFullNodesZero, "gaia", "v17.3.0", ibc.ChainConfig{
+		ModifyGenesis: cosmos.ModifyGenesis(shortVoteGenesis),
+	})
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	enableBlockDB := false
+	ctx, _, _, _ := interchaintest.BuildInitialChain(t, chains, enableBlockDB)
+
+	results, err := chain.Upgrade(ctx, cosmos.UpgradeOptions{
+		HeightDelta:        haltHeightDelta,
+		Deposit:            "500000000" + chain.Config().Denom, // greater than min deposit
+		BlocksAfterUpgrade: blocksAfterUpgrade,
+		ResumeTimeout:      45 * time.Second,
+	}, cosmos.UpgradeStep{
+		Repository: "ghcr.io/strangelove-ventures/heighliner/gaia",
+		Version:    "v18.1.0",
+		Name:       "v18",
+	})
+	require.NoError(t, err, "error upgrading chain")
+	require.Len(t, results, 1)
+
+	res := results[0]
+	require.NotZero(t, res.ProposalID)
+	// The chain halts at the upgrade height of the proposal.
+	require.Equal(t, res.UpgradeHeight, res.HaltedHeight, "halted height is not equal to upgrade height")
+
+	height, err := chain.Height(ctx)
+	require.NoError(t, err, "error fetching height after upgrade")
+	require.GreaterOrEqual(t, height, res.HaltedHeight+blocksAfterUpgrade)
+
+	for _, n := range chain.Nodes() {
+		require.Equal(t, "v18.1.0", n.Image.Version)
+	}
+
+	t.Logf("Upgraded at height %d in %s (halt %s, restart %s, resume %s)", res.HaltedHeight, res.Total, res.Halt, res.Restart, res.Resume)
+}
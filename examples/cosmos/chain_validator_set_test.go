@@ -0,0 +1,52 @@
+package cosmos_test
+
+import (
+	"testing"
+
+	"github.com/strangelove-ventures/interchaintest/v8"
+	"github.com/strangelove-ventures/interchaintest/v8/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGaiaValidatorSet(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	chains := interchaintest.CreateChainWithConfig(t, 2, numFullNodesZero, "gaia", "v17.3.0", ibc.ChainConfig{})
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	enableBlockDB := false
+	ctx, _, _, _ := interchaintest.BuildInitialChain(t, chains, enableBlockDB)
+
+	vals, err := chain.CometValidators(ctx)
+	require.NoError(t, err)
+	require.Len(t, vals, 2)
+
+	// Add a validator after genesis.
+	val, err := chain.AddValidator(ctx, cosmos.AddValidatorOptions{})
+	require.NoError(t, err)
+	require.Len(t, chain.Validators, 3)
+
+	vals, err = chain.CometValidators(ctx)
+	require.NoError(t, err)
+	require.Len(t, vals, 3)
+
+	consAddr, err := val.ConsensusAddress(ctx)
+	require.NoError(t, err)
+
+	// Remove a genesis validator.
+	require.NoError(t, chain.RemoveValidator(ctx, chain.Validators[1]))
+	require.Len(t, chain.Validators, 2)
+
+	vals, err = chain.CometValidators(ctx)
+	require.NoError(t, err)
+	require.Len(t, vals, 2)
+	require.NoError(t, chain.WaitForCometValidator(ctx, consAddr, true, 1), "added validator left the validator set")
+
+	// Validators are added with new indexes after a removal.
+	val, err = chain.AddValidator(ctx, cosmos.AddValidatorOptions{})
+	require.NoError(t, err)
+	require.Equal(t, 3, val.Index)
+}
@@ -3,6 +3,7 @@ package cosmos_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"cosmossdk.io/math"
 	"github.com/strangelove-ventures/interchaintest/v8"
@@ -99,3 +100,95 @@ func TestCometMock(t *testing.T) {
 	require.NoError(t, err)
 	require.EqualValues(t, initBal, endBal)
 }
+
+func TestCometMockControl(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			Name:      "juno",
+			ChainName: "juno",
+			Version:   "v19.0.0-alpha.3",
+			ChainConfig: ibc.ChainConfig{
+				Denom:         "ujuno",
+				Bech32Prefix:  "juno",
+				CoinType:      "118",
+				ModifyGenesis: cosmos.ModifyGenesis(sdk47Genesis),
+				CometMock: ibc.CometMockConfig{
+					Image:        ibc.NewDockerImage("ghcr.io/informalsystems/cometmock", "v0.37.x", "1025:1025"),
+					ManualBlocks: true,
+				},
+				GasPrices: "0ujuno",
+			},
+			NumValidators: &numValsOne,
+			NumFullNodes:  &numFullNodesZero,
+		},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	chain := chains[0].(*cosmos.CosmosChain)
+
+	ic := interchaintest.NewInterchain().
+		AddChain(chain)
+
+	ctx := context.Background()
+	client, network := interchaintest.DockerSetup(t)
+
+	require.NoError(t, ic.Build(ctx, nil, interchaintest.InterchainBuildOptions{
+		TestName:         t.Name(),
+		Client:           client,
+		NetworkID:        network,
+		SkipPathCreation: true,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	mock, err := chain.CometMock()
+	require.NoError(t, err)
+
+	t.Run("advance blocks", func(t *testing.T) {
+		height, err := chain.Height(ctx)
+		require.NoError(t, err)
+
+		require.NoError(t, mock.AdvanceBlocks(ctx, 5))
+
+		newHeight, err := chain.Height(ctx)
+		require.NoError(t, err)
+		require.Equal(t, height+5, newHeight)
+	})
+
+	t.Run("advance time", func(t *testing.T) {
+		block, err := chain.GetNode().Client.Block(ctx, nil)
+		require.NoError(t, err)
+
+		_, err = mock.AdvanceTime(ctx, 24*time.Hour)
+		require.NoError(t, err)
+		require.NoError(t, mock.AdvanceBlocks(ctx, 1))
+
+		newBlock, err := chain.GetNode().Client.Block(ctx, nil)
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, newBlock.Block.Time.Sub(block.Block.Time), 24*time.Hour)
+	})
+
+	t.Run("signing", func(t *testing.T) {
+		val := chain.Validators[0]
+		valcons, err := val.ValconsAddress(ctx)
+		require.NoError(t, err)
+		before, err := chain.SlashingQuerySigningInfo(ctx, valcons)
+		require.NoError(t, err)
+
+		require.NoError(t, mock.SetSigning(ctx, val, false))
+		require.NoError(t, mock.AdvanceBlocks(ctx, 5))
+		require.NoError(t, mock.SetSigning(ctx, val, true))
+		require.NoError(t, mock.AdvanceBlocks(ctx, 1))
+
+		after, err := chain.SlashingQuerySigningInfo(ctx, valcons)
+		require.NoError(t, err)
+		require.Greater(t, after.MissedBlocksCounter, before.MissedBlocksCounter)
+	})
+}
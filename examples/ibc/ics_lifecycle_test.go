@@ -0,0 +1,121 @@
+package ibc_test
+
+import (
+	"context"
+	"testing"
+
+	"cosmossdk.io/math"
+	interchaintest "github.com/strangelove-ventures/interchaintest/v8"
+	"github.com/strangelove-ventures/interchaintest/v8/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/strangelove-ventures/interchaintest/v8/relayer"
+	"github.com/strangelove-ventures/interchaintest/v8/testreporter"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// This tests the lifecycle of an Interchain Security consumer after its launch:
+// consumer key assignment, validator set change maturity and consumer removal.
+// go test -timeout 3000s -run ^TestICSLifecycle$ github.com/strangelove-ventures/interchaintest/v8/examples/ibc -v
+func TestICSLifecycle(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+	t.Parallel()
+
+	ctx := context.Background()
+	const version = "v4.0.0"
+
+	validators := 2
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			Name: "ics-provider", Version: version,
+			NumValidators: &validators, NumFullNodes: &numFullNodes,
+			ChainConfig: ibc.ChainConfig{GasAdjustment: 1.5, ChainID: providerChainID, TrustingPeriod: "336h"},
+		},
+		{
+			Name: "ics-consumer", Version: version,
+			NumValidators: &validators, NumFullNodes: &numFullNodes,
+			ChainConfig: ibc.ChainConfig{GasAdjustment: 1.5, ChainID: "consumer-1", Bech32Prefix: "consumer"},
+		},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	provider, consumer := chains[0].(*cosmos.CosmosChain), chains[1].(*cosmos.CosmosChain)
+
+	client, network := interchaintest.DockerSetup(t)
+	r := interchaintest.NewBuiltinRelayerFactory(
+		ibc.CosmosRly,
+		zaptest.NewLogger(t),
+		relayer.StartupFlags("--block-history", "100"),
+	).Build(t, client, network)
+
+	const ibcPath = "ics-path"
+	ic := interchaintest.NewInterchain().
+		AddChain(provider).
+		AddChain(consumer).
+		AddRelayer(r, "relayer").
+		AddProviderConsumerLink(interchaintest.ProviderConsumerLink{
+			Provider: provider,
+			Consumer: consumer,
+			Relayer:  r,
+			Path:     ibcPath,
+		})
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	require.NoError(t, provider.FinishICSProviderSetup(ctx, r, eRep, ibcPath))
+
+	chainID := consumer.Config().ChainID
+
+	t.Run("assign consumer key", func(t *testing.T) {
+		_, err := provider.ICSAssignConsumerKey(ctx, consumer, 1, 30)
+		require.NoError(t, err)
+
+		providerValcons, err := provider.Validators[1].ValconsAddress(ctx)
+		require.NoError(t, err)
+		assigned, err := provider.ICSQueryValidatorConsumerAddr(ctx, chainID, providerValcons)
+		require.NoError(t, err)
+		require.NotEmpty(t, assigned)
+	})
+
+	t.Run("unbonding held until VSC matured", func(t *testing.T) {
+		users := interchaintest.GetAndFundTestUsers(t, ctx, "default", math.NewInt(10_000_000), provider)
+		user := users[0]
+
+		valoper, err := provider.Validators[0].KeyBech32(ctx, "validator", "val")
+		require.NoError(t, err)
+
+		amt := "1000000" + provider.Config().Denom
+		require.NoError(t, provider.GetNode().StakingDelegate(ctx, user.KeyName(), valoper, amt))
+		require.NoError(t, provider.GetNode().StakingUnbond(ctx, user.KeyName(), valoper, amt))
+
+		require.NoError(t, provider.ICSWaitForVSCMatured(ctx, chainID, 50))
+
+		onHold, err := provider.ICSUnbondingOnHold(ctx, user.FormattedAddress(), valoper)
+		require.NoError(t, err)
+		require.False(t, onHold)
+	})
+
+	t.Run("remove consumer", func(t *testing.T) {
+		require.NoError(t, provider.ICSRemoveConsumer(ctx, consumer, 0, 50))
+
+		chains, err := provider.ICSQueryConsumerChains(ctx)
+		require.NoError(t, err)
+		for _, ch := range chains {
+			require.NotEqual(t, chainID, ch.ChainId)
+		}
+	})
+}
@@ -0,0 +1,91 @@
+package ibc_test
+
+import (
+	"context"
+	"testing"
+
+	interchaintest "github.com/strangelove-ventures/interchaintest/v8"
+	"github.com/strangelove-ventures/interchaintest/v8/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/strangelove-ventures/interchaintest/v8/relayer"
+	"github.com/strangelove-ventures/interchaintest/v8/testreporter"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+// This tests an opt-in Interchain Security consumer, secured by the only provider validator opting in to validate it.
+// go test -timeout 3000s -run ^TestICSOptIn$ github.com/strangelove-ventures/interchaintest/v8/examples/ibc -v
+func TestICSOptIn(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping in short mode")
+	}
+	t.Parallel()
+
+	ctx := context.Background()
+	const version = "v5.0.0"
+
+	validators := 2
+
+	cf := interchaintest.NewBuiltinChainFactory(zaptest.NewLogger(t), []*interchaintest.ChainSpec{
+		{
+			Name: "ics-provider", Version: version,
+			NumValidators: &validators, NumFullNodes: &numFullNodes,
+			ChainConfig: ibc.ChainConfig{GasAdjustment: 1.5, ChainID: providerChainID, TrustingPeriod: "336h"},
+		},
+		{
+			Name: "ics-consumer", Version: version,
+			NumValidators: &validators, NumFullNodes: &numFullNodes,
+			ChainConfig: ibc.ChainConfig{GasAdjustment: 1.5, ChainID: "consumer-1", Bech32Prefix: "consumer", InterchainSecurityConfig: ibc.ICSConfig{
+				PowerShaping: ibc.ICSPowerShaping{OptIn: true},
+				ConsumerOptIn: func(i int) bool {
+					return i == 0
+				},
+			}},
+		},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+	provider, consumer := chains[0].(*cosmos.CosmosChain), chains[1].(*cosmos.CosmosChain)
+
+	client, network := interchaintest.DockerSetup(t)
+	r := interchaintest.NewBuiltinRelayerFactory(
+		ibc.CosmosRly,
+		zaptest.NewLogger(t),
+		relayer.StartupFlags("--block-history", "100"),
+	).Build(t, client, network)
+
+	const ibcPath = "ics-path"
+	ic := interchaintest.NewInterchain().
+		AddChain(provider).
+		AddChain(consumer).
+		AddRelayer(r, "relayer").
+		AddProviderConsumerLink(interchaintest.ProviderConsumerLink{
+			Provider: provider,
+			Consumer: consumer,
+			Relayer:  r,
+			Path:     ibcPath,
+		})
+
+	rep := testreporter.NewNopReporter()
+	eRep := rep.RelayerExecReporter(t)
+
+	require.NoError(t, ic.Build(ctx, eRep, interchaintest.InterchainBuildOptions{
+		TestName:  t.Name(),
+		Client:    client,
+		NetworkID: network,
+	}))
+	t.Cleanup(func() {
+		_ = ic.Close()
+	})
+
+	require.NoError(t, provider.FinishICSProviderSetup(ctx, r, eRep, ibcPath))
+
+	vals, err := consumer.CometValidators(ctx)
+	require.NoError(t, err)
+	require.Len(t, vals, 1)
+
+	consAddr, err := consumer.Validators[0].ConsensusAddress(ctx)
+	require.NoError(t, err)
+	require.Equal(t, consAddr, vals[0].Address.String())
+}
@@ -186,13 +186,13 @@ func TestInterchainQueriesWASM(t *testing.T) {
 	require.NoError(t, err)
 
 	icqWasmPortId := "wasm." + contractAddr
-	destPort := "icqhost"
+	destPort := cosmosChain.ICQHostPort
 	// Create channel between icq wasm contract <> icq module.
 	err = r.CreateChannel(ctx, eRep, pathName, ibc.CreateChannelOptions{
 		SourcePortName: icqWasmPortId,
 		DestPortName:   destPort,
 		Order:          ibc.Unordered,
-		Version:        "icq-1",
+		Version:        cosmosChain.ICQVersion,
 	})
 	require.NoError(t, err)
 	err = testutil.WaitForBlocks(ctx, 5, chain1, chain2)
@@ -286,9 +286,11 @@ func TestInterchainQueriesWASM(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, resp)
 
-	// Wait a few blocks for query to be sent to counterparty.
-	err = testutil.WaitForBlocks(ctx, 5, chain1, chain2)
+	// Wait for the host chain to answer the query, and the relayer to deliver the acknowledgement.
+	ack, err := chain1CChain.ICQWaitForAck(ctx, resp.TxHash, 20)
 	require.NoError(t, err)
+	require.True(t, ack.Success(), "interchain query failed: %s", ack.Error)
+	require.Len(t, ack.Responses, len(query.Query.Requests))
 
 	// Check the results from the interchain query above.
 	cmd = []string{
@@ -0,0 +1,36 @@
+package ibc
+
+import (
+	transfertypes "github.com/cosmos/ibc-go/v8/modules/apps/transfer/types"
+)
+
+// DenomTrace returns the full denom trace, e.g. "transfer/channel-1/uatom",
+// of baseDenom after it is transferred over each of the given channels in order.
+// Each channel is the channel end on the sending chain of that transfer, e.g. as returned by GetTransferChannel,
+// and its counterparty is the end on the receiving chain.
+//
+// baseDenom is the full denom trace on the first sending chain, since an ibc/... hash cannot be traced back.
+// Transfers of a voucher back over the channel it arrived on unwind the trace.
+func DenomTrace(baseDenom string, channels ...ChannelOutput) string {
+	trace := baseDenom
+	for _, ch := range channels {
+		trace = nextDenomTrace(trace, ch)
+	}
+	return trace
+}
+
+// IBCDenom returns the denom, e.g. "ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2",
+// under which baseDenom is held after it is transferred over each of the given channels in order.
+// See DenomTrace for the meaning of the arguments.
+// If the trace unwinds back to its base denom, the base denom is returned.
+func IBCDenom(baseDenom string, channels ...ChannelOutput) string {
+	return transfertypes.ParseDenomTrace(DenomTrace(baseDenom, channels...)).IBCDenom()
+}
+
+// nextDenomTrace returns the trace of denom once received over ch.
+func nextDenomTrace(trace string, ch ChannelOutput) string {
+	if transfertypes.ReceiverChainIsSource(ch.PortID, ch.ChannelID, trace) {
+		return trace[len(transfertypes.GetDenomPrefix(ch.PortID, ch.ChannelID)):]
+	}
+	return transfertypes.GetPrefixedDenom(ch.Counterparty.PortID, ch.Counterparty.ChannelID, trace)
+}
@@ -0,0 +1,29 @@
+package ibc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	transfertypes "github.com/cosmos/ibc-go/v8/modules/apps/transfer/types"
+)
+
+func TestDenomTrace(t *testing.T) {
+	ab := ChannelOutput{PortID: "transfer", ChannelID: "channel-0", Counterparty: ChannelCounterparty{PortID: "transfer", ChannelID: "channel-1"}}
+	bc := ChannelOutput{PortID: "transfer", ChannelID: "channel-2", Counterparty: ChannelCounterparty{PortID: "transfer", ChannelID: "channel-3"}}
+	ba := ChannelOutput{PortID: "transfer", ChannelID: "channel-1", Counterparty: ChannelCounterparty{PortID: "transfer", ChannelID: "channel-0"}}
+
+	require.Equal(t, "uatom", DenomTrace("uatom"))
+	require.Equal(t, "uatom", IBCDenom("uatom"))
+
+	require.Equal(t, "transfer/channel-1/uatom", DenomTrace("uatom", ab))
+	require.Equal(t, "transfer/channel-3/transfer/channel-1/uatom", DenomTrace("uatom", ab, bc))
+	require.Equal(t,
+		transfertypes.ParseDenomTrace(transfertypes.GetPrefixedDenom("transfer", "channel-3", "transfer/channel-1/uatom")).IBCDenom(),
+		IBCDenom("uatom", ab, bc),
+	)
+
+	// Sending back to the origin unwinds the trace.
+	require.Equal(t, "uatom", DenomTrace("uatom", ab, ba))
+	require.Equal(t, "uatom", IBCDenom("uatom", ab, ba))
+}
@@ -0,0 +1,280 @@
+package ibc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"cosmossdk.io/math"
+
+	transfertypes "github.com/cosmos/ibc-go/v8/modules/apps/transfer/types"
+)
+
+// DefaultForwardReceiver is the receiver used on intermediate chains of a ForwardRoute
+// when a hop does not set one. Packet-forward-middleware ignores it and derives its own escrow address.
+const DefaultForwardReceiver = "pfm"
+
+// ForwardHop is a single hop of a packet-forward-middleware route.
+type ForwardHop struct {
+	// Channel is the channel end on the sending chain of this hop, e.g. as returned by GetTransferChannel.
+	// Its counterparty is used to compute the denom on the receiving chain.
+	Channel ChannelOutput
+
+	// Chain is the receiving chain of this hop.
+	Chain Chain
+
+	// Receiver on Chain. Required on the last hop,
+	// and defaults to DefaultForwardReceiver on the other hops.
+	Receiver string
+
+	// Timeout and Retries are passed to packet-forward-middleware for this hop.
+	// They are ignored on the first hop, which is sent with the TransferOptions of the transfer.
+	// Zero values use the middleware defaults.
+	Timeout time.Duration
+	Retries *uint8
+}
+
+// ForwardRoute is a route of IBC transfers through packet-forward-middleware,
+// from a source chain over every hop in order.
+type ForwardRoute struct {
+	Hops []ForwardHop
+}
+
+// NewForwardRoute returns a route over the given hops.
+func NewForwardRoute(hops ...ForwardHop) ForwardRoute {
+	return ForwardRoute{Hops: hops}
+}
+
+// forwardMemo is the packet-forward-middleware memo of a single hop.
+type forwardMemo struct {
+	Forward forwardMetadata `json:"forward"`
+}
+
+type forwardMetadata struct {
+	Receiver string       `json:"receiver"`
+	Port     string       `json:"port"`
+	Channel  string       `json:"channel"`
+	Timeout  string       `json:"timeout,omitempty"`
+	Retries  *uint8       `json:"retries,omitempty"`
+	Next     *forwardMemo `json:"next,omitempty"`
+}
+
+// Validate returns an error if the route is empty or a hop is incomplete.
+func (r ForwardRoute) Validate() error {
+	if len(r.Hops) == 0 {
+		return errors.New("forward route has no hops")
+	}
+	for i, hop := range r.Hops {
+		if hop.Channel.ChannelID == "" || hop.Channel.PortID == "" {
+			return fmt.Errorf("hop %d: channel and port must be set", i)
+		}
+		if hop.Channel.Counterparty.ChannelID == "" || hop.Channel.Counterparty.PortID == "" {
+			return fmt.Errorf("hop %d: counterparty channel and port must be set", i)
+		}
+		if hop.Chain == nil {
+			return fmt.Errorf("hop %d: chain must be set", i)
+		}
+	}
+	if r.Hops[len(r.Hops)-1].Receiver == "" {
+		return errors.New("the last hop must have a receiver")
+	}
+	return nil
+}
+
+// receiver returns the receiver of hop i.
+func (r ForwardRoute) receiver(i int) string {
+	if rcv := r.Hops[i].Receiver; rcv != "" {
+		return rcv
+	}
+	return DefaultForwardReceiver
+}
+
+// Memo returns the nested packet-forward-middleware memo for every hop after the first,
+// to be set as TransferOptions.Memo of the transfer over the first hop.
+// It returns an empty memo for a single hop route.
+func (r ForwardRoute) Memo() (string, error) {
+	if err := r.Validate(); err != nil {
+		return "", err
+	}
+
+	var next *forwardMemo
+	for i := len(r.Hops) - 1; i >= 1; i-- {
+		hop := r.Hops[i]
+		m := &forwardMemo{Forward: forwardMetadata{
+			Receiver: r.receiver(i),
+			Port:     hop.Channel.PortID,
+			Channel:  hop.Channel.ChannelID,
+			Retries:  hop.Retries,
+			Next:     next,
+		}}
+		if hop.Timeout > 0 {
+			m.Forward.Timeout = hop.Timeout.String()
+		}
+		next = m
+	}
+	if next == nil {
+		return "", nil
+	}
+
+	bz, err := json.Marshal(next)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal forward memo: %w", err)
+	}
+	return string(bz), nil
+}
+
+// Denom returns the denom received on the chain of each hop for a transfer of baseDenom.
+// baseDenom is the full denom trace on the source chain, e.g. "uatom" or "transfer/channel-0/uatom",
+// since an ibc/... hash cannot be traced back.
+// Hops that send a voucher back towards its origin unwind the trace accordingly.
+func (r ForwardRoute) Denom(baseDenom string) []string {
+	trace := baseDenom
+	denoms := make([]string, len(r.Hops))
+	for i, hop := range r.Hops {
+		trace = nextDenomTrace(trace, hop.Channel)
+		denoms[i] = transfertypes.ParseDenomTrace(trace).IBCDenom()
+	}
+	return denoms
+}
+
+// FinalDenom returns the denom received on the last chain of the route for a transfer of baseDenom.
+// See Denom for the expected format of baseDenom.
+func (r ForwardRoute) FinalDenom(baseDenom string) string {
+	denoms := r.Denom(baseDenom)
+	return denoms[len(denoms)-1]
+}
+
+// ForwardTransfer is a transfer sent over a ForwardRoute.
+type ForwardTransfer struct {
+	Route  ForwardRoute
+	Source Chain
+	Amount math.Int
+
+	// Tx is the transfer over the first hop.
+	Tx Tx
+
+	// startHeights are the heights of each hop's sending chain before the transfer was sent.
+	startHeights []int64
+}
+
+// Transfer sends amount of baseDenom from keyName on src over the route.
+// baseDenom is the denom on src, in the format described by Denom.
+// The memo of opts is replaced by the route's memo.
+func (r ForwardRoute) Transfer(ctx context.Context, src Chain, keyName, baseDenom string, amount math.Int, opts TransferOptions) (*ForwardTransfer, error) {
+	memo, err := r.Memo()
+	if err != nil {
+		return nil, err
+	}
+	opts.Memo = memo
+
+	// Record where to start looking for the acknowledgement of every hop.
+	startHeights := make([]int64, len(r.Hops))
+	for i := range r.Hops {
+		sender := src
+		if i > 0 {
+			sender = r.Hops[i-1].Chain
+		}
+		h, err := sender.Height(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get height of %s: %w", sender.Config().ChainID, err)
+		}
+		startHeights[i] = h
+	}
+
+	// The bank denom on src is the hash of the trace, if any.
+	denom := transfertypes.ParseDenomTrace(baseDenom).IBCDenom()
+
+	tx, err := src.SendIBCTransfer(ctx, r.Hops[0].Channel.ChannelID, keyName, WalletAmount{
+		Address: r.receiver(0),
+		Denom:   denom,
+		Amount:  amount,
+	}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send transfer over %s: %w", r.Hops[0].Channel.ChannelID, err)
+	}
+
+	return &ForwardTransfer{
+		Route:        r,
+		Source:       src,
+		Amount:       amount,
+		Tx:           tx,
+		startHeights: startHeights,
+	}, nil
+}
+
+// WaitForLanding waits until the packet of every hop is acknowledged successfully,
+// searching at most maxBlocks blocks on the sending chain of each hop,
+// which means the funds landed on the last chain of the route.
+//
+// Acknowledgements are polled hop by hop, starting from the first hop.
+// Forwarded packets are matched by channel, receiver and amount, so forwarding fees are not supported.
+func (t *ForwardTransfer) WaitForLanding(ctx context.Context, maxBlocks int64) error {
+	for i, hop := range t.Route.Hops {
+		sender := t.Source
+		if i > 0 {
+			sender = t.Route.Hops[i-1].Chain
+		}
+		chainID := sender.Config().ChainID
+
+		match := func(p Packet) bool {
+			if i == 0 {
+				return p.Equal(t.Tx.Packet)
+			}
+			if p.SourcePort != hop.Channel.PortID || p.SourceChannel != hop.Channel.ChannelID {
+				return false
+			}
+			var data transfertypes.FungibleTokenPacketData
+			if err := json.Unmarshal(p.Data, &data); err != nil {
+				return false
+			}
+			return data.Receiver == t.Route.receiver(i) && data.Amount == t.Amount.String()
+		}
+
+		ack, err := pollForAck(ctx, sender, t.startHeights[i], t.startHeights[i]+maxBlocks, match)
+		if err != nil {
+			return fmt.Errorf("hop %d from %s over %s: %w", i, chainID, hop.Channel.ChannelID, err)
+		}
+
+		var res struct {
+			Error string `json:"error"`
+		}
+		if err := json.Unmarshal(ack.Acknowledgement, &res); err == nil && res.Error != "" {
+			return fmt.Errorf("hop %d from %s over %s failed: %s", i, chainID, hop.Channel.ChannelID, res.Error)
+		}
+	}
+
+	return nil
+}
+
+// pollForAck searches the acknowledgements of chain from startHeight to maxHeight for a packet matching match,
+// waiting for blocks to be produced when needed.
+func pollForAck(ctx context.Context, chain Chain, startHeight, maxHeight int64, match func(Packet) bool) (PacketAcknowledgement, error) {
+	for height := startHeight; height <= maxHeight; {
+		cur, err := chain.Height(ctx)
+		if err != nil {
+			return PacketAcknowledgement{}, err
+		}
+		if height > cur {
+			select {
+			case <-ctx.Done():
+				return PacketAcknowledgement{}, ctx.Err()
+			case <-time.After(100 * time.Millisecond):
+			}
+			continue
+		}
+
+		acks, err := chain.Acknowledgements(ctx, height)
+		if err != nil {
+			return PacketAcknowledgement{}, err
+		}
+		for _, ack := range acks {
+			if match(ack.Packet) {
+				return ack, nil
+			}
+		}
+		height++
+	}
+	return PacketAcknowledgement{}, fmt.Errorf("acknowledgement not found between heights %d and %d", startHeight, maxHeight)
+}
@@ -0,0 +1,77 @@
+package ibc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	transfertypes "github.com/cosmos/ibc-go/v8/modules/apps/transfer/types"
+)
+
+// stubChain is only used for its identity.
+type stubChain struct {
+	Chain
+}
+
+func transferChannel(channelID, counterpartyChannelID string) ChannelOutput {
+	return ChannelOutput{
+		PortID:       "transfer",
+		ChannelID:    channelID,
+		Counterparty: ChannelCounterparty{PortID: "transfer", ChannelID: counterpartyChannelID},
+	}
+}
+
+func TestForwardRoute_Memo(t *testing.T) {
+	retries := uint8(2)
+	route := NewForwardRoute(
+		ForwardHop{Channel: transferChannel("channel-0", "channel-1"), Chain: stubChain{}},
+		ForwardHop{Channel: transferChannel("channel-2", "channel-3"), Chain: stubChain{}, Timeout: 10 * time.Minute, Retries: &retries},
+		ForwardHop{Channel: transferChannel("channel-4", "channel-5"), Chain: stubChain{}, Receiver: "cosmos1final"},
+	)
+
+	memo, err := route.Memo()
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+  "forward": {
+    "receiver": "pfm",
+    "port": "transfer",
+    "channel": "channel-2",
+    "timeout": "10m0s",
+    "retries": 2,
+    "next": {
+      "forward": {
+        "receiver": "cosmos1final",
+        "port": "transfer",
+        "channel": "channel-4"
+      }
+    }
+  }
+}`, memo)
+
+	single := NewForwardRoute(ForwardHop{Channel: transferChannel("channel-0", "channel-1"), Chain: stubChain{}, Receiver: "cosmos1final"})
+	memo, err = single.Memo()
+	require.NoError(t, err)
+	require.Empty(t, memo)
+
+	_, err = NewForwardRoute(ForwardHop{Channel: transferChannel("channel-0", "channel-1"), Chain: stubChain{}}).Memo()
+	require.EqualError(t, err, "the last hop must have a receiver")
+}
+
+func TestForwardRoute_Denom(t *testing.T) {
+	route := NewForwardRoute(
+		ForwardHop{Channel: transferChannel("channel-0", "channel-1"), Chain: stubChain{}},
+		ForwardHop{Channel: transferChannel("channel-2", "channel-3"), Chain: stubChain{}, Receiver: "cosmos1final"},
+	)
+
+	require.Equal(t, []string{
+		transfertypes.ParseDenomTrace("transfer/channel-1/uatom").IBCDenom(),
+		transfertypes.ParseDenomTrace("transfer/channel-3/transfer/channel-1/uatom").IBCDenom(),
+	}, route.Denom("uatom"))
+
+	// Sending a voucher back over the channel it arrived on unwinds the trace.
+	unwind := NewForwardRoute(
+		ForwardHop{Channel: transferChannel("channel-0", "channel-1"), Chain: stubChain{}, Receiver: "cosmos1final"},
+	)
+	require.Equal(t, "uatom", unwind.FinalDenom("transfer/channel-0/uatom"))
+}
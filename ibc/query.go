@@ -0,0 +1,68 @@
+package ibc
+
+import (
+	"context"
+
+	clienttypes "github.com/cosmos/ibc-go/v8/modules/core/02-client/types"
+	conntypes "github.com/cosmos/ibc-go/v8/modules/core/03-connection/types"
+	chantypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
+	ibcexported "github.com/cosmos/ibc-go/v8/modules/core/exported"
+)
+
+// IBCQueryChain is an optional interface of Chain, for chains that can query their IBC state directly over gRPC.
+// Unlike the Get methods of Relayer, it does not depend on a relayer or on parsing its output.
+//
+//	if c, ok := chain.(ibc.IBCQueryChain); ok {
+//		ch, err := c.IBCQuerier().QueryChannel(ctx, "transfer", "channel-0")
+//	}
+type IBCQueryChain interface {
+	// IBCQuerier returns a querier of the IBC state of the chain, through the gRPC connection of its full node.
+	IBCQuerier() IBCQuerier
+}
+
+// IBCQuerier queries the IBC state of a chain, as returned by IBCQueryChain.
+type IBCQuerier interface {
+	// QueryClientStates returns every client on the chain.
+	QueryClientStates(ctx context.Context) (clienttypes.IdentifiedClientStates, error)
+
+	// QueryClientState returns the state of the given client.
+	QueryClientState(ctx context.Context, clientID string) (ibcexported.ClientState, error)
+
+	// QueryClientStatus returns the status of the given client, e.g. ibcexported.Active or ibcexported.Frozen.
+	QueryClientStatus(ctx context.Context, clientID string) (ibcexported.Status, error)
+
+	// QueryConsensusState returns the consensus state of the given client at height,
+	// or at the latest height of the client if height is zero.
+	QueryConsensusState(ctx context.Context, clientID string, height clienttypes.Height) (ibcexported.ConsensusState, error)
+
+	// QueryConnections returns every connection on the chain.
+	QueryConnections(ctx context.Context) ([]*conntypes.IdentifiedConnection, error)
+
+	// QueryConnection returns the given connection.
+	QueryConnection(ctx context.Context, connectionID string) (*conntypes.ConnectionEnd, error)
+
+	// QueryChannels returns every channel on the chain.
+	QueryChannels(ctx context.Context) ([]*chantypes.IdentifiedChannel, error)
+
+	// QueryChannel returns the given channel.
+	QueryChannel(ctx context.Context, portID, channelID string) (*chantypes.Channel, error)
+
+	// QueryPacketCommitments returns the commitments of every packet sent over the channel
+	// that is not yet acknowledged or timed out.
+	QueryPacketCommitments(ctx context.Context, portID, channelID string) ([]*chantypes.PacketState, error)
+
+	// QueryPacketCommitment returns the commitment of the packet sent over the channel with the given sequence,
+	// or nil if there is none, e.g. because the packet was acknowledged.
+	QueryPacketCommitment(ctx context.Context, portID, channelID string, sequence uint64) ([]byte, error)
+
+	// QueryPacketReceipt reports whether the packet with the given sequence was received over the channel.
+	// Receipts are only written for unordered channels.
+	QueryPacketReceipt(ctx context.Context, portID, channelID string, sequence uint64) (bool, error)
+
+	// QueryPacketAcknowledgements returns the acknowledgements written for packets received over the channel.
+	QueryPacketAcknowledgements(ctx context.Context, portID, channelID string) ([]*chantypes.PacketState, error)
+
+	// QueryPacketAcknowledgement returns the acknowledgement commitment written for the packet with the given sequence
+	// received over the channel, or nil if there is none.
+	QueryPacketAcknowledgement(ctx context.Context, portID, channelID string, sequence uint64) ([]byte, error)
+}
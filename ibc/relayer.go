@@ -3,6 +3,7 @@ package ibc
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	chantypes "github.com/cosmos/ibc-go/v8/modules/core/04-channel/types"
@@ -113,13 +114,49 @@ type Relayer interface {
 // GetTransferChannel will return the transfer channel assuming only one client,
 // one connection, and one channel with "transfer" port exists between two chains.
 func GetTransferChannel(ctx context.Context, r Relayer, rep RelayerExecReporter, srcChainID, dstChainID string) (*ChannelOutput, error) {
+	srcConnectionID, err := getConnectionID(ctx, r, rep, srcChainID, dstChainID)
+	if err != nil {
+		return nil, err
+	}
+
+	srcChannels, err := r.GetChannels(ctx, rep, srcChainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get channels on source chain: %w", err)
+	}
+
+	if len(srcChannels) == 0 {
+		return nil, fmt.Errorf("no channels exist on source chain: %w", err)
+	}
+
+	var srcChan *ChannelOutput
+	for _, channel := range srcChannels {
+		ch := channel
+
+		if len(ch.ConnectionHops) == 1 && ch.ConnectionHops[0] == srcConnectionID && ch.PortID == "transfer" {
+			if srcChan != nil {
+				return nil, fmt.Errorf("found multiple transfer channels on %s for connection %s", srcChainID, srcConnectionID)
+			}
+			srcChan = &ch
+		}
+	}
+
+	if srcChan == nil {
+		return nil, fmt.Errorf("no transfer channel found between chains: %s - %s", srcChainID, dstChainID)
+	}
+
+	return srcChan, nil
+}
+
+// getConnectionID returns the ID of the connection on srcChainID to dstChainID,
+// assuming only one client and one connection exist between the two chains.
+func getConnectionID(ctx context.Context, r Relayer, rep RelayerExecReporter, srcChainID, dstChainID string) (string, error) {
 	srcClients, err := r.GetClients(ctx, rep, srcChainID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get clients on source chain: %w", err)
+		return "", fmt.Errorf("failed to get clients on source chain: %w", err)
 	}
 
 	if len(srcClients) == 0 {
-		return nil, fmt.Errorf("no clients exist on source chain: %w", err)
+		return "", fmt.Errorf("no clients exist on source chain: %w", err)
 	}
 
 	var srcClientID string
@@ -127,65 +164,149 @@ func GetTransferChannel(ctx context.Context, r Relayer, rep RelayerExecReporter,
 		// TODO continue for expired clients
 		if client.ClientState.ChainID == dstChainID {
 			if srcClientID != "" {
-				return nil, fmt.Errorf("found multiple clients on %s tracking %s", srcChainID, dstChainID)
+				return "", fmt.Errorf("found multiple clients on %s tracking %s", srcChainID, dstChainID)
 			}
 			srcClientID = client.ClientID
 		}
 	}
 
 	if srcClientID == "" {
-		return nil, fmt.Errorf("unable to find client on %s tracking %s", srcChainID, dstChainID)
+		return "", fmt.Errorf("unable to find client on %s tracking %s", srcChainID, dstChainID)
 	}
 
 	srcConnections, err := r.GetConnections(ctx, rep, srcChainID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get connections on source chain: %w", err)
+		return "", fmt.Errorf("failed to get connections on source chain: %w", err)
 	}
 
 	if len(srcConnections) == 0 {
-		return nil, fmt.Errorf("no connections exist on source chain: %w", err)
+		return "", fmt.Errorf("no connections exist on source chain: %w", err)
 	}
 
 	var srcConnectionID string
 	for _, connection := range srcConnections {
 		if connection.ClientID == srcClientID {
 			if srcConnectionID != "" {
-				return nil, fmt.Errorf("found multiple connections on %s for client %s", srcChainID, srcClientID)
+				return "", fmt.Errorf("found multiple connections on %s for client %s", srcChainID, srcClientID)
 			}
 			srcConnectionID = connection.ID
 		}
 	}
 
 	if srcConnectionID == "" {
-		return nil, fmt.Errorf("unable to find connection on %s for client %s", srcChainID, srcClientID)
+		return "", fmt.Errorf("unable to find connection on %s for client %s", srcChainID, srcClientID)
 	}
 
-	srcChannels, err := r.GetChannels(ctx, rep, srcChainID)
+	return srcConnectionID, nil
+}
+
+// MultiHopChannel is a channel across an ordered route of chains, as returned by GetMultiHopChannel.
+type MultiHopChannel struct {
+	// ChainIDs is the route of the channel, from the source chain to the destination chain.
+	ChainIDs []string
+
+	// Src and Dst are the channel ends on the first and last chain of the route.
+	// Their ConnectionHops list the connections on every chain of the route, starting from their own chain.
+	Src, Dst ChannelOutput
+}
+
+// ConnectionID returns the connection on the given chain of the route
+// that leads towards the destination chain if towardsDst is true, or towards the source chain otherwise.
+// It returns false if chainID is not on the route or has no connection in that direction.
+func (c MultiHopChannel) ConnectionID(chainID string, towardsDst bool) (string, bool) {
+	n := len(c.ChainIDs)
+	for i, id := range c.ChainIDs {
+		if id != chainID {
+			continue
+		}
+		if towardsDst {
+			if i == n-1 {
+				return "", false
+			}
+			return c.Src.ConnectionHops[i], true
+		}
+		if i == 0 {
+			return "", false
+		}
+		return c.Dst.ConnectionHops[n-1-i], true
+	}
+	return "", false
+}
+
+// GetMultiHopChannel returns both ends of the channel on portID across the given route of chains,
+// assuming only one client and one connection exist between each pair of adjacent chains
+// and only one channel on portID uses those connections.
+// interchaintest does not create multi-hop channels, since no supported relayer opens them;
+// the channel must be opened by other means.
+func GetMultiHopChannel(ctx context.Context, r Relayer, rep RelayerExecReporter, portID string, chainIDs ...string) (*MultiHopChannel, error) {
+	n := len(chainIDs)
+	if n < 2 {
+		return nil, fmt.Errorf("a channel route needs at least 2 chains, got %d", n)
+	}
+
+	srcHops := make([]string, n-1)
+	dstHops := make([]string, n-1)
+	for i := 0; i < n-1; i++ {
+		var err error
+		if srcHops[i], err = getConnectionID(ctx, r, rep, chainIDs[i], chainIDs[i+1]); err != nil {
+			return nil, err
+		}
+		if dstHops[n-2-i], err = getConnectionID(ctx, r, rep, chainIDs[i+1], chainIDs[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	srcChainID, dstChainID := chainIDs[0], chainIDs[n-1]
+
+	srcChan, err := findChannel(ctx, r, rep, srcChainID, func(ch ChannelOutput) bool {
+		return ch.PortID == portID && slices.Equal(ch.ConnectionHops, srcHops)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get channels on source chain: %w", err)
+		return nil, err
 	}
 
-	if len(srcChannels) == 0 {
-		return nil, fmt.Errorf("no channels exist on source chain: %w", err)
+	dstChan, err := findChannel(ctx, r, rep, dstChainID, func(ch ChannelOutput) bool {
+		return ch.PortID == srcChan.Counterparty.PortID && ch.ChannelID == srcChan.Counterparty.ChannelID
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	var srcChan *ChannelOutput
-	for _, channel := range srcChannels {
+	if !slices.Equal(dstChan.ConnectionHops, dstHops) {
+		return nil, fmt.Errorf("channel %s on %s has connection hops %v, expected %v", dstChan.ChannelID, dstChainID, dstChan.ConnectionHops, dstHops)
+	}
+
+	return &MultiHopChannel{
+		ChainIDs: chainIDs,
+		Src:      *srcChan,
+		Dst:      *dstChan,
+	}, nil
+}
+
+// findChannel returns the only channel on chainID matching match.
+func findChannel(ctx context.Context, r Relayer, rep RelayerExecReporter, chainID string, match func(ChannelOutput) bool) (*ChannelOutput, error) {
+	channels, err := r.GetChannels(ctx, rep, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get channels on %s: %w", chainID, err)
+	}
+
+	var found *ChannelOutput
+	for _, channel := range channels {
 		ch := channel
 
-		if len(ch.ConnectionHops) == 1 && ch.ConnectionHops[0] == srcConnectionID && ch.PortID == "transfer" {
-			if srcChan != nil {
-				return nil, fmt.Errorf("found multiple transfer channels on %s for connection %s", srcChainID, srcConnectionID)
+		if match(ch) {
+			if found != nil {
+				return nil, fmt.Errorf("found multiple matching channels on %s: %s and %s", chainID, found.ChannelID, ch.ChannelID)
 			}
-			srcChan = &ch
+			found = &ch
 		}
 	}
 
-	if srcChan == nil {
-		return nil, fmt.Errorf("no transfer channel found between chains: %s - %s", srcChainID, dstChainID)
+	if found == nil {
+		return nil, fmt.Errorf("no matching channel found on %s", chainID)
 	}
 
-	return srcChan, nil
+	return found, nil
 }
 
 // RelyaerExecResult holds the details of a call to Relayer.Exec.
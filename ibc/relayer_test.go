@@ -1,6 +1,7 @@
 package ibc
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -63,3 +64,79 @@ func TestClientOptsConfigured(t *testing.T) {
 	}
 	require.Error(t, opts.Validate())
 }
+
+// routeRelayer serves canned query results for the chains a - b - c.
+type routeRelayer struct {
+	Relayer
+
+	clients     map[string]ClientOutputs
+	connections map[string]ConnectionOutputs
+	channels    map[string][]ChannelOutput
+}
+
+func (r routeRelayer) GetClients(_ context.Context, _ RelayerExecReporter, chainID string) (ClientOutputs, error) {
+	return r.clients[chainID], nil
+}
+
+func (r routeRelayer) GetConnections(_ context.Context, _ RelayerExecReporter, chainID string) (ConnectionOutputs, error) {
+	return r.connections[chainID], nil
+}
+
+func (r routeRelayer) GetChannels(_ context.Context, _ RelayerExecReporter, chainID string) ([]ChannelOutput, error) {
+	return r.channels[chainID], nil
+}
+
+func TestGetMultiHopChannel(t *testing.T) {
+	client := func(id, chainID string) *ClientOutput {
+		return &ClientOutput{ClientID: id, ClientState: ClientState{ChainID: chainID}}
+	}
+
+	r := routeRelayer{
+		clients: map[string]ClientOutputs{
+			"a": {client("07-tendermint-0", "b")},
+			"b": {client("07-tendermint-0", "a"), client("07-tendermint-1", "c")},
+			"c": {client("07-tendermint-0", "b")},
+		},
+		connections: map[string]ConnectionOutputs{
+			"a": {{ID: "connection-0", ClientID: "07-tendermint-0"}},
+			"b": {{ID: "connection-0", ClientID: "07-tendermint-0"}, {ID: "connection-1", ClientID: "07-tendermint-1"}},
+			"c": {{ID: "connection-0", ClientID: "07-tendermint-0"}},
+		},
+		channels: map[string][]ChannelOutput{
+			"a": {
+				{ChannelID: "channel-0", PortID: "transfer", ConnectionHops: []string{"connection-0"}},
+				{
+					ChannelID: "channel-1", PortID: "transfer", ConnectionHops: []string{"connection-0", "connection-1"},
+					Counterparty: ChannelCounterparty{PortID: "transfer", ChannelID: "channel-3"},
+				},
+			},
+			"c": {
+				{ChannelID: "channel-3", PortID: "transfer", ConnectionHops: []string{"connection-0", "connection-0"}},
+			},
+		},
+	}
+
+	ch, err := GetMultiHopChannel(context.Background(), r, nil, "transfer", "a", "b", "c")
+	require.NoError(t, err)
+	require.Equal(t, "channel-1", ch.Src.ChannelID)
+	require.Equal(t, "channel-3", ch.Dst.ChannelID)
+
+	conn, ok := ch.ConnectionID("b", true)
+	require.True(t, ok)
+	require.Equal(t, "connection-1", conn)
+
+	conn, ok = ch.ConnectionID("b", false)
+	require.True(t, ok)
+	require.Equal(t, "connection-0", conn)
+
+	_, ok = ch.ConnectionID("c", true)
+	require.False(t, ok)
+
+	// The single-hop transfer channel is still resolved as before.
+	transfer, err := GetTransferChannel(context.Background(), r, nil, "a", "b")
+	require.NoError(t, err)
+	require.Equal(t, "channel-0", transfer.ChannelID)
+
+	_, err = GetMultiHopChannel(context.Background(), r, nil, "transfer", "a")
+	require.Error(t, err)
+}
@@ -62,6 +62,9 @@ type ChainConfig struct {
 	GasAdjustment float64 `yaml:"gas-adjustment"`
 	// Default gas limit for transactions. May be empty, "auto", or a number.
 	Gas string `yaml:"gas" default:"auto"`
+	// How transactions are signed and broadcast, used for cosmos chains only.
+	// Defaults to TxModeCLI.
+	TxMode TxMode `yaml:"tx-mode"`
 	// Trusting period of the chain.
 	TrustingPeriod string `yaml:"trusting-period"`
 	// Do not use docker host mount.
@@ -207,6 +210,10 @@ func (c ChainConfig) MergeChainSpecConfig(other ChainConfig) ChainConfig {
 		c.Gas = other.Gas
 	}
 
+	if other.TxMode != "" {
+		c.TxMode = other.TxMode
+	}
+
 	if other.TrustingPeriod != "" {
 		c.TrustingPeriod = other.TrustingPeriod
 	}
@@ -313,8 +320,30 @@ type DockerImage struct {
 type CometMockConfig struct {
 	Image       DockerImage `yaml:"image"`
 	BlockTimeMs int         `yaml:"block-time"`
+
+	// ManualBlocks disables the automatic block production of CometMock.
+	// Blocks are produced on demand, or when a transaction is broadcast.
+	// It only takes effect when the chain starts, as CometMock has no way to pause or resume
+	// the block production of a running chain.
+	ManualBlocks bool `yaml:"manual-blocks"`
 }
 
+// TxMode selects how a chain signs and broadcasts transactions.
+type TxMode string
+
+const (
+	// TxModeCLI executes the tx subcommand of the chain binary in a node container.
+	// It is used when TxMode is empty.
+	TxModeCLI TxMode = "cli"
+
+	// TxModeGRPC creates keys and signs transactions in Go with the in-memory keyring of the chain,
+	// and broadcasts them over gRPC instead of running the tx subcommand of the chain binary.
+	// The helpers of modules without Go types in this repository still run the tx subcommand:
+	// tokenfactory, osmosis, the ICS v6 consumer helpers and StoreClientContract.
+	// AuthzExec also generates the msgs it executes with the CLI.
+	TxModeGRPC TxMode = "grpc"
+)
+
 func NewDockerImage(repository, version, uidGID string) DockerImage {
 	return DockerImage{
 		Repository: repository,
@@ -458,6 +487,57 @@ type ICSConfig struct {
 	ProviderVerOverride     string         `yaml:"provider,omitempty" json:"provider,omitempty"`
 	ConsumerVerOverride     string         `yaml:"consumer,omitempty" json:"consumer,omitempty"`
 	ConsumerCopyProviderKey func(int) bool `yaml:"-" json:"-"`
+
+	// LaunchMode is how the provider launches the consumer. Defaults to ICSLaunchGov.
+	LaunchMode ICSLaunchMode `yaml:"launch-mode,omitempty" json:"launch_mode,omitempty"`
+
+	// PowerShaping restricts the provider validators securing the consumer.
+	PowerShaping ICSPowerShaping `yaml:"power-shaping,omitempty" json:"power_shaping,omitempty"`
+
+	// ConsumerOptIn reports whether the i-th provider validator opts in to validate the consumer before its spawn time.
+	// When nil, all validators opt in to an opt-in consumer, i.e. a permissionless one or one with ICSPowerShaping.OptIn,
+	// and none of them to a top N consumer, whose validators in the top N are opted in by the provider.
+	ConsumerOptIn func(int) bool `yaml:"-" json:"-"`
+}
+
+// ICSLaunchMode is how a provider launches a consumer chain.
+type ICSLaunchMode string
+
+const (
+	// ICSLaunchGov launches the consumer with a consumer addition proposal, for providers before ICS v6.
+	// The consumer is top N, unless ICSPowerShaping.OptIn is set with ICS v5 providers.
+	ICSLaunchGov ICSLaunchMode = ""
+
+	// ICSLaunchPermissionless launches the consumer with a create-consumer transaction, for providers from ICS v6.
+	// The consumer is opt-in, ICSPowerShaping.TopN must be 0.
+	ICSLaunchPermissionless ICSLaunchMode = "permissionless"
+)
+
+// ICSPowerShaping are the power shaping parameters of a consumer chain, supported by providers from ICS v5.
+type ICSPowerShaping struct {
+	// TopN is the percentage of the voting power of the provider whose validators must validate the consumer.
+	// 0 makes the consumer opt-in. Defaults to 95 for ICSLaunchGov, the replicated security of earlier ICS versions,
+	// set OptIn to launch an opt-in consumer with governance.
+	TopN uint32 `yaml:"top-n,omitempty" json:"top_n,omitempty"`
+
+	// OptIn makes an ICSLaunchGov consumer opt-in, with a TopN of 0.
+	OptIn bool `yaml:"opt-in,omitempty" json:"opt_in,omitempty"`
+
+	// ValidatorsPowerCap caps the percentage of the consumer voting power of a validator, 0 for no cap.
+	ValidatorsPowerCap uint32 `yaml:"validators-power-cap,omitempty" json:"validators_power_cap,omitempty"`
+
+	// ValidatorSetCap caps the number of validators of the consumer, 0 for no cap.
+	ValidatorSetCap uint32 `yaml:"validator-set-cap,omitempty" json:"validator_set_cap,omitempty"`
+
+	// Allowlist and Denylist are the provider consensus addresses of the validators allowed and denied to validate the consumer.
+	Allowlist []string `yaml:"allowlist,omitempty" json:"allowlist,omitempty"`
+	Denylist  []string `yaml:"denylist,omitempty" json:"denylist,omitempty"`
+
+	// MinStake is the minimum bonded amount of the validators of the consumer, from ICS v6.
+	MinStake uint64 `yaml:"min-stake,omitempty" json:"min_stake,omitempty"`
+
+	// AllowInactiveVals lets validators outside of the provider active set validate the consumer, from ICS v6.
+	AllowInactiveVals bool `yaml:"allow-inactive-vals,omitempty" json:"allow_inactive_vals,omitempty"`
 }
 
 // GenesisConfig is used to start a chain from a pre-defined genesis state.
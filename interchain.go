@@ -2,6 +2,7 @@ package interchaintest
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"path/filepath"
@@ -39,6 +40,10 @@ type Interchain struct {
 	// Set to true after Build is called once.
 	built bool
 
+	// If set, builder errors are collected in errs instead of panicking.
+	collectErrors bool
+	errs          []error
+
 	// Map of relayer-chain pairs to address and mnemonic, set during Build().
 	// Not yet exposed through any exported API.
 	relayerWallets map[relayerChain]ibc.Wallet
@@ -93,6 +98,31 @@ func NewInterchain() *Interchain {
 	}
 }
 
+// CollectErrors switches ic to an error-collecting builder mode.
+// Instead of panicking, AddChain, AddRelayer, AddLink and AddProviderConsumerLink
+// skip the invalid entry and record the problem, and calling Build more than once returns an error.
+// All recorded problems are returned together by Validate, and by Build before anything is started.
+//
+// CollectErrors should be called before any other builder method.
+func (ic *Interchain) CollectErrors() *Interchain {
+	ic.collectErrors = true
+	return ic
+}
+
+// Validate returns every problem recorded by the builder methods since CollectErrors was called,
+// or nil if there were none.
+func (ic *Interchain) Validate() error {
+	return errors.Join(ic.errs...)
+}
+
+// fail panics with err, or records it if ic is collecting errors.
+func (ic *Interchain) fail(err error) {
+	if !ic.collectErrors {
+		panic(err)
+	}
+	ic.errs = append(ic.errs, err)
+}
+
 // relayerPath is a tuple of a relayer and a path name.
 type relayerPath struct {
 	Relayer ibc.Relayer
@@ -102,10 +132,12 @@ type relayerPath struct {
 // AddChain adds the given chain to the Interchain,
 // using the chain ID reported by the chain's config.
 // If the given chain already exists,
-// or if another chain with the same configured chain ID exists, AddChain panics.
+// or if another chain with the same configured chain ID exists, AddChain panics,
+// unless ic is collecting errors.
 func (ic *Interchain) AddChain(chain ibc.Chain, additionalGenesisWallets ...ibc.WalletAmount) *Interchain {
 	if chain == nil {
-		panic(fmt.Errorf("cannot add nil chain"))
+		ic.fail(fmt.Errorf("cannot add nil chain"))
+		return ic
 	}
 
 	newID := chain.Config().ChainID
@@ -113,13 +145,16 @@ func (ic *Interchain) AddChain(chain ibc.Chain, additionalGenesisWallets ...ibc.
 
 	for c, id := range ic.chains {
 		if c == chain {
-			panic(fmt.Errorf("chain %v was already added", c))
+			ic.fail(fmt.Errorf("chain %v was already added", c))
+			return ic
 		}
 		if id == newID {
-			panic(fmt.Errorf("a chain with ID %s already exists", id))
+			ic.fail(fmt.Errorf("a chain with ID %s already exists", id))
+			return ic
 		}
 		if c.Config().Name == newName {
-			panic(fmt.Errorf("a chain with name %s already exists", newName))
+			ic.fail(fmt.Errorf("a chain with name %s already exists", newName))
+			return ic
 		}
 	}
 
@@ -138,17 +173,21 @@ func (ic *Interchain) AddChain(chain ibc.Chain, additionalGenesisWallets ...ibc.
 }
 
 // AddRelayer adds the given relayer with the given name to the Interchain.
+// If the relayer or its name was already added, AddRelayer panics, unless ic is collecting errors.
 func (ic *Interchain) AddRelayer(relayer ibc.Relayer, name string) *Interchain {
 	if relayer == nil {
-		panic(fmt.Errorf("cannot add nil relayer"))
+		ic.fail(fmt.Errorf("cannot add nil relayer"))
+		return ic
 	}
 
 	for r, n := range ic.relayers {
 		if r == relayer {
-			panic(fmt.Errorf("relayer %v was already added", r))
+			ic.fail(fmt.Errorf("relayer %v was already added", r))
+			return ic
 		}
 		if n == name {
-			panic(fmt.Errorf("a relayer with name %s already exists", n))
+			ic.fail(fmt.Errorf("a relayer with name %s already exists", n))
+			return ic
 		}
 	}
 
@@ -156,23 +195,31 @@ func (ic *Interchain) AddRelayer(relayer ibc.Relayer, name string) *Interchain {
 	return ic
 }
 
-// AddLink adds the given link to the Interchain.
-// If any validation fails, AddLink panics.
+// AddProviderConsumerLink adds the given provider/consumer link to the Interchain.
+// If any validation fails, AddProviderConsumerLink panics, unless ic is collecting errors.
 func (ic *Interchain) AddProviderConsumerLink(link ProviderConsumerLink) *Interchain {
+	if link.Provider == nil || link.Consumer == nil {
+		ic.fail(fmt.Errorf("cannot add provider/consumer link with nil chain"))
+		return ic
+	}
 	if _, exists := ic.chains[link.Provider]; !exists {
 		cfg := link.Provider.Config()
-		panic(fmt.Errorf("chain with name=%s and id=%s was never added to Interchain", cfg.Name, cfg.ChainID))
+		ic.fail(fmt.Errorf("chain with name=%s and id=%s was never added to Interchain", cfg.Name, cfg.ChainID))
+		return ic
 	}
 	if _, exists := ic.chains[link.Consumer]; !exists {
 		cfg := link.Consumer.Config()
-		panic(fmt.Errorf("chain with name=%s and id=%s was never added to Interchain", cfg.Name, cfg.ChainID))
+		ic.fail(fmt.Errorf("chain with name=%s and id=%s was never added to Interchain", cfg.Name, cfg.ChainID))
+		return ic
 	}
 	if _, exists := ic.relayers[link.Relayer]; !exists {
-		panic(fmt.Errorf("relayer %v was never added to Interchain", link.Relayer))
+		ic.fail(fmt.Errorf("relayer %v was never added to Interchain", link.Relayer))
+		return ic
 	}
 
 	if link.Provider == link.Consumer {
-		panic(fmt.Errorf("chains must be different (both were %v)", link.Provider))
+		ic.fail(fmt.Errorf("chains must be different (both were %v)", link.Provider))
+		return ic
 	}
 
 	key := relayerPath{
@@ -181,7 +228,8 @@ func (ic *Interchain) AddProviderConsumerLink(link ProviderConsumerLink) *Interc
 	}
 
 	if _, exists := ic.providerConsumerLinks[key]; exists {
-		panic(fmt.Errorf("relayer %q already has a path named %q", key.Relayer, key.Path))
+		ic.fail(fmt.Errorf("relayer %q already has a path named %q", key.Relayer, key.Path))
+		return ic
 	}
 
 	ic.providerConsumerLinks[key] = providerConsumerLink{
@@ -238,22 +286,30 @@ type ProviderConsumerLink struct {
 }
 
 // AddLink adds the given link to the Interchain.
-// If any validation fails, AddLink panics.
+// If any validation fails, AddLink panics, unless ic is collecting errors.
 func (ic *Interchain) AddLink(link InterchainLink) *Interchain {
+	if link.Chain1 == nil || link.Chain2 == nil {
+		ic.fail(fmt.Errorf("cannot add link with nil chain"))
+		return ic
+	}
 	if _, exists := ic.chains[link.Chain1]; !exists {
 		cfg := link.Chain1.Config()
-		panic(fmt.Errorf("chain with name=%s and id=%s was never added to Interchain", cfg.Name, cfg.ChainID))
+		ic.fail(fmt.Errorf("chain with name=%s and id=%s was never added to Interchain", cfg.Name, cfg.ChainID))
+		return ic
 	}
 	if _, exists := ic.chains[link.Chain2]; !exists {
 		cfg := link.Chain2.Config()
-		panic(fmt.Errorf("chain with name=%s and id=%s was never added to Interchain", cfg.Name, cfg.ChainID))
+		ic.fail(fmt.Errorf("chain with name=%s and id=%s was never added to Interchain", cfg.Name, cfg.ChainID))
+		return ic
 	}
 	if _, exists := ic.relayers[link.Relayer]; !exists {
-		panic(fmt.Errorf("relayer %v was never added to Interchain", link.Relayer))
+		ic.fail(fmt.Errorf("relayer %v was never added to Interchain", link.Relayer))
+		return ic
 	}
 
 	if link.Chain1 == link.Chain2 {
-		panic(fmt.Errorf("chains must be different (both were %v)", link.Chain1))
+		ic.fail(fmt.Errorf("chains must be different (both were %v)", link.Chain1))
+		return ic
 	}
 
 	key := relayerPath{
@@ -262,7 +318,8 @@ func (ic *Interchain) AddLink(link InterchainLink) *Interchain {
 	}
 
 	if _, exists := ic.links[key]; exists {
-		panic(fmt.Errorf("relayer %q already has a path named %q", key.Relayer, key.Path))
+		ic.fail(fmt.Errorf("relayer %q already has a path named %q", key.Relayer, key.Path))
+		return ic
 	}
 
 	ic.links[key] = interchainLink{
@@ -291,15 +348,28 @@ type InterchainBuildOptions struct {
 
 	// If set, saves block history to a sqlite3 database to aid debugging.
 	BlockDatabaseFile string
+
+	// If set, the chains and relayers are restored from this snapshot,
+	// instead of starting from genesis and creating relayer paths.
+	// See InterchainSnapshot for the requirements on the Interchain.
+	Snapshot *InterchainSnapshot
 }
 
 // Build starts all the chains and configures the relayers associated with the Interchain.
 // It is the caller's responsibility to directly call StartRelayer on the relayer implementations.
 //
-// Calling Build more than once will cause a panic.
+// Calling Build more than once will cause a panic, unless ic is collecting errors.
+// When collecting errors, Build returns the result of Validate without starting anything if it is non-nil.
 func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecReporter, opts InterchainBuildOptions) error {
 	if ic.built {
-		panic(fmt.Errorf("Interchain.Build called more than once"))
+		err := fmt.Errorf("Interchain.Build called more than once")
+		if ic.collectErrors {
+			return err
+		}
+		panic(err)
+	}
+	if err := ic.Validate(); err != nil {
+		return fmt.Errorf("invalid Interchain: %w", err)
 	}
 	ic.built = true
 
@@ -325,6 +395,17 @@ func (ic *Interchain) Build(ctx context.Context, rep *testreporter.RelayerExecRe
 
 	ic.log.Info("Chains initialized")
 
+	if opts.Snapshot != nil {
+		if err := ic.restoreSnapshot(ctx, opts.Snapshot); err != nil {
+			return err
+		}
+
+		if err := ic.cs.TrackBlocks(ctx, opts.TestName, opts.BlockDatabaseFile, opts.GitSha); err != nil {
+			return fmt.Errorf("failed to track blocks: %w", err)
+		}
+		return nil
+	}
+
 	err := ic.generateRelayerWallets(ctx) // Build the relayer wallet mapping.
 	if err != nil {
 		return err
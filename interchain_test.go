@@ -570,3 +570,56 @@ func assertTransactionIsValid(t *testing.T, resp sdk.TxResponse) {
 	require.NotEmpty(t, resp.TxHash)
 	require.NotEmpty(t, resp.Events)
 }
+
+func TestInterchain_CollectErrors(t *testing.T) {
+	cf := interchaintest.NewBuiltinChainFactory(zap.NewNop(), []*interchaintest.ChainSpec{
+		{Name: testutil.TestSimd, ChainName: "c1", Version: testutil.SimdVersion, ChainConfig: ibc.ChainConfig{ChainID: "chain-0"}, NumValidators: &numVals, NumFullNodes: &numFullNodesZero},
+		{Name: testutil.TestSimd, ChainName: "c2", Version: testutil.SimdVersion, ChainConfig: ibc.ChainConfig{ChainID: "chain-0"}, NumValidators: &numVals, NumFullNodes: &numFullNodesZero},
+	})
+
+	chains, err := cf.Chains(t.Name())
+	require.NoError(t, err)
+
+	var r1, r2 rly.CosmosRelayer
+
+	ic := interchaintest.NewInterchain().
+		CollectErrors().
+		AddChain(chains[0]).
+		AddChain(chains[1]).
+		AddChain(nil).
+		AddRelayer(&r1, "r").
+		AddRelayer(&r2, "r").
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  chains[0],
+			Chain2:  chains[0],
+			Relayer: &r1,
+			Path:    "p",
+		}).
+		AddLink(interchaintest.InterchainLink{
+			Chain1:  chains[0],
+			Relayer: &r1,
+			Path:    "nil-chain",
+		}).
+		AddProviderConsumerLink(interchaintest.ProviderConsumerLink{
+			Consumer: chains[1],
+			Relayer:  &r1,
+			Path:     "nil-provider",
+		})
+
+	err = ic.Validate()
+	require.Error(t, err)
+	for _, msg := range []string{
+		"a chain with ID chain-0 already exists",
+		"cannot add nil chain",
+		"a relayer with name r already exists",
+		fmt.Sprintf("chains must be different (both were %v)", chains[0]),
+		"cannot add link with nil chain",
+		"cannot add provider/consumer link with nil chain",
+	} {
+		require.ErrorContains(t, err, msg)
+	}
+
+	// Build reports the same problems without starting anything.
+	rep := testreporter.NewNopReporter().RelayerExecReporter(t)
+	require.ErrorContains(t, ic.Build(context.Background(), rep, interchaintest.InterchainBuildOptions{}), "cannot add nil chain")
+}
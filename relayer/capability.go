@@ -17,6 +17,28 @@ const (
 
 	// Whether the relayer supports a one-off flush command.
 	Flush
+
+	// Whether the relayer can complete the handshake of, and relay packets over, ordered channels.
+	OrderedChannel
+
+	// Whether the relayer completes closing a channel on the counterparty
+	// after it was closed on one end, e.g. by a timeout on an ordered channel.
+	ChannelClose
+
+	// Whether the relayer supports channels wrapped in ICS-29 fee middleware.
+	FeeMiddleware
+
+	// Whether the relayer completes channel upgrade handshakes.
+	ChannelUpgrade
+
+	// Whether the relayer honors a client trusting period and fails to update expired clients.
+	ClientExpiry
+
+	// Whether the relayer detects conflicting client updates and submits the misbehaviour.
+	Misbehaviour
+
+	// Whether the relayer fails to update frozen clients.
+	FrozenClient
 )
 
 // FullCapabilities returns a mapping of all known relayer features to true,
@@ -29,5 +51,14 @@ func FullCapabilities() map[Capability]bool {
 		HeightTimeout:    true,
 
 		Flush: true,
+
+		OrderedChannel: true,
+		ChannelClose:   true,
+		FeeMiddleware:  true,
+		ChannelUpgrade: true,
+
+		ClientExpiry: true,
+		Misbehaviour: true,
+		FrozenClient: true,
 	}
 }
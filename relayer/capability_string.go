@@ -11,11 +11,18 @@ func _() {
 	_ = x[TimestampTimeout-0]
 	_ = x[HeightTimeout-1]
 	_ = x[Flush-2]
+	_ = x[OrderedChannel-3]
+	_ = x[ChannelClose-4]
+	_ = x[FeeMiddleware-5]
+	_ = x[ChannelUpgrade-6]
+	_ = x[ClientExpiry-7]
+	_ = x[Misbehaviour-8]
+	_ = x[FrozenClient-9]
 }
 
-const _Capability_name = "TimestampTimeoutHeightTimeoutFlush"
+const _Capability_name = "TimestampTimeoutHeightTimeoutFlushOrderedChannelChannelCloseFeeMiddlewareChannelUpgradeClientExpiryMisbehaviourFrozenClient"
 
-var _Capability_index = [...]uint8{0, 16, 29, 34}
+var _Capability_index = [...]uint8{0, 16, 29, 34, 48, 60, 73, 87, 99, 111, 123}
 
 func (i Capability) String() string {
 	if i < 0 || i >= Capability(len(_Capability_index)-1) {
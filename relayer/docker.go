@@ -149,6 +149,38 @@ func (r *DockerRelayer) ReadFileFromHomeDir(ctx context.Context, relativePath st
 	return bytes, nil
 }
 
+// ExportHomeDir writes the entire relayer home directory to w as a tar stream.
+// The relayer must not be running, so that the archive is consistent.
+func (r *DockerRelayer) ExportHomeDir(ctx context.Context, w io.Writer) error {
+	if r.containerLifecycle != nil {
+		return fmt.Errorf("cannot export home directory while relayer is running")
+	}
+	va := dockerutil.NewVolumeArchiver(r.log, r.client, r.testName)
+	if err := va.Export(ctx, r.volumeName, w); err != nil {
+		return fmt.Errorf("failed to export home directory: %w", err)
+	}
+	return nil
+}
+
+// ImportHomeDir extracts a tar stream produced by ExportHomeDir into the relayer home directory,
+// applying rewrite to the content of every file if it is non-nil.
+func (r *DockerRelayer) ImportHomeDir(ctx context.Context, rd io.Reader, rewrite dockerutil.RewriteFunc) error {
+	if r.containerLifecycle != nil {
+		return fmt.Errorf("cannot import home directory while relayer is running")
+	}
+	va := dockerutil.NewVolumeArchiver(r.log, r.client, r.testName)
+	if err := va.Import(ctx, r.volumeName, rd, rewrite); err != nil {
+		return fmt.Errorf("failed to import home directory: %w", err)
+	}
+	return nil
+}
+
+// RestoreWallet records a wallet whose key already exists in the relayer home directory,
+// such as after ImportHomeDir, without executing the relayer.
+func (r *DockerRelayer) RestoreWallet(chainID, keyName, address, mnemonic string) {
+	r.wallets[chainID] = r.c.CreateWallet(keyName, address, mnemonic)
+}
+
 // Modify a toml config file in relayer home directory.
 func (r *DockerRelayer) ModifyTomlConfigFile(ctx context.Context, relativePath string, modification testutil.Toml) error {
 	return testutil.ModifyTomlConfigFile(ctx, r.log, r.client, r.testName, r.volumeName, relativePath, modification)
@@ -466,6 +498,15 @@ func (r *DockerRelayer) ResumeRelayer(ctx context.Context) error {
 	return r.client.ContainerUnpause(ctx, r.containerLifecycle.ContainerID())
 }
 
+// ContainerID returns the ID of the container started by StartRelayer,
+// or an empty string if the relayer is not running.
+func (r *DockerRelayer) ContainerID() string {
+	if r.containerLifecycle == nil {
+		return ""
+	}
+	return r.containerLifecycle.ContainerID()
+}
+
 func (r *DockerRelayer) ContainerImage() ibc.DockerImage {
 	if r.customImage != nil {
 		return *r.customImage
@@ -34,6 +34,12 @@ var (
 	parseRestoreKeyOutputPattern = regexp.MustCompile(`\((.*)\)`)
 )
 
+// Capabilities returns the set of capabilities of hermes.
+// Unlike the Go relayer, hermes completes channel upgrade handshakes since v1.8.0.
+func Capabilities() map[relayer.Capability]bool {
+	return relayer.FullCapabilities()
+}
+
 // Relayer is the ibc.Relayer implementation for hermes.
 type Relayer struct {
 	*relayer.DockerRelayer
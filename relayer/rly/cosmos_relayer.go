@@ -75,8 +75,12 @@ const (
 // Note, this API may change if the rly package eventually needs
 // to distinguish between multiple rly versions.
 func Capabilities() map[relayer.Capability]bool {
-	// RC1 matches the full set of capabilities as of writing.
-	return relayer.FullCapabilities()
+	caps := relayer.FullCapabilities()
+
+	// The Go relayer does not relay channel upgrade handshakes.
+	caps[relayer.ChannelUpgrade] = false
+
+	return caps
 }
 
 func ChainConfigToCosmosRelayerChainConfig(chainConfig ibc.ChainConfig, keyName, rpcAddr, gprcAddr string) CosmosRelayerChainConfig {
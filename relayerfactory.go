@@ -115,8 +115,7 @@ func (f builtinRelayerFactory) Capabilities() map[relayer.Capability]bool {
 	case ibc.CosmosRly:
 		return rly.Capabilities()
 	case ibc.Hermes:
-		// TODO: specify capability for hermes.
-		return rly.Capabilities()
+		return hermes.Capabilities()
 	case ibc.Hyperspace:
 		panic("capabilities are not defined for Hyperspace relayer.")
 	default:
@@ -0,0 +1,556 @@
+package interchaintest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/strangelove-ventures/interchaintest/v8/chain/cosmos"
+	"github.com/strangelove-ventures/interchaintest/v8/dockerutil"
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+)
+
+// snapshotManifestFile is the name of the manifest inside a snapshot directory.
+const snapshotManifestFile = "snapshot.json"
+
+// InterchainSnapshot is a point-in-time copy of a built Interchain:
+// the home directory of every chain node and relayer, and the IBC topology that was created.
+//
+// A snapshot is taken with (*Interchain).Snapshot and restored into a later test
+// by setting InterchainBuildOptions.Snapshot, which skips genesis, relayer key setup and path creation.
+// The restored Interchain must declare the same chains (matched by chain ID, with the same node counts),
+// relayers (matched by name) and links as the one that was snapshotted.
+//
+// Only cosmos.CosmosChain chains without CometMock are supported,
+// and relayers must embed relayer.DockerRelayer.
+// Relayers that keep path state in memory, such as hermes, only have their home directory restored.
+// Chain-level sidecars are not snapshotted.
+//
+// Light clients keep the timestamps of the snapshot, so a snapshot must be restored
+// within the trusting period of its clients.
+type InterchainSnapshot struct {
+	// Dir is the host directory holding the manifest and the archives.
+	Dir string `json:"-"`
+
+	// TestName is the name of the test the snapshot was taken in.
+	TestName string `json:"test_name"`
+
+	CreatedAt time.Time `json:"created_at"`
+
+	Chains   []ChainSnapshot   `json:"chains"`
+	Relayers []RelayerSnapshot `json:"relayers"`
+	Links    []LinkSnapshot    `json:"links"`
+}
+
+// ChainSnapshot describes the snapshotted state of a single chain.
+type ChainSnapshot struct {
+	ChainID string `json:"chain_id"`
+	Name    string `json:"name"`
+
+	// Height of the chain when its nodes were stopped.
+	Height int64 `json:"height"`
+
+	Nodes []NodeSnapshot `json:"nodes"`
+}
+
+// NodeSnapshot describes the archive of a single chain node home directory.
+type NodeSnapshot struct {
+	Validator bool `json:"validator"`
+	Index     int  `json:"index"`
+
+	// Container and host names at the time of the snapshot, rewritten on restore.
+	ContainerName string `json:"container_name"`
+	HostName      string `json:"host_name"`
+
+	// Archive is the path of the tar archive, relative to the snapshot directory.
+	Archive string `json:"archive"`
+}
+
+// RelayerSnapshot describes the archive of a single relayer home directory.
+type RelayerSnapshot struct {
+	// Name is the name given to the relayer in (*Interchain).AddRelayer.
+	Name string `json:"name"`
+
+	// Archive is the path of the tar archive, relative to the snapshot directory.
+	Archive string `json:"archive"`
+
+	Wallets []RelayerWalletSnapshot `json:"wallets"`
+}
+
+// RelayerWalletSnapshot is a relayer wallet whose key is stored in the relayer home directory.
+type RelayerWalletSnapshot struct {
+	ChainID  string `json:"chain_id"`
+	KeyName  string `json:"key_name"`
+	Address  string `json:"address"`
+	Mnemonic string `json:"mnemonic"`
+}
+
+// LinkSnapshot is the IBC topology resolved for a relayer path when the snapshot was taken.
+type LinkSnapshot struct {
+	Relayer string `json:"relayer"`
+	Path    string `json:"path"`
+
+	// ChainIDs of the linked chains. For provider/consumer links, the provider is first.
+	ChainIDs [2]string `json:"chain_ids"`
+
+	ProviderConsumer bool `json:"provider_consumer"`
+
+	// Keyed by chain ID.
+	Clients     map[string]ibc.ClientOutputs     `json:"clients"`
+	Connections map[string]ibc.ConnectionOutputs `json:"connections"`
+	Channels    map[string][]ibc.ChannelOutput   `json:"channels"`
+}
+
+// Link returns the snapshotted topology of the named relayer path.
+func (s *InterchainSnapshot) Link(relayerName, pathName string) (LinkSnapshot, bool) {
+	for _, l := range s.Links {
+		if l.Relayer == relayerName && l.Path == pathName {
+			return l, true
+		}
+	}
+	return LinkSnapshot{}, false
+}
+
+// chain returns the snapshot of the chain with the given ID.
+func (s *InterchainSnapshot) chain(chainID string) (ChainSnapshot, bool) {
+	for _, c := range s.Chains {
+		if c.ChainID == chainID {
+			return c, true
+		}
+	}
+	return ChainSnapshot{}, false
+}
+
+// relayer returns the snapshot of the relayer with the given name.
+func (s *InterchainSnapshot) relayer(name string) (RelayerSnapshot, bool) {
+	for _, r := range s.Relayers {
+		if r.Name == name {
+			return r, true
+		}
+	}
+	return RelayerSnapshot{}, false
+}
+
+// save writes the manifest into s.Dir.
+func (s *InterchainSnapshot) save() error {
+	bz, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal snapshot manifest: %w", err)
+	}
+	return os.WriteFile(filepath.Join(s.Dir, snapshotManifestFile), bz, 0o644)
+}
+
+// LoadInterchainSnapshot reads a snapshot previously written to dir by (*Interchain).Snapshot.
+func LoadInterchainSnapshot(dir string) (*InterchainSnapshot, error) {
+	bz, err := os.ReadFile(filepath.Join(dir, snapshotManifestFile))
+	if err != nil {
+		return nil, fmt.Errorf("read snapshot manifest: %w", err)
+	}
+
+	var s InterchainSnapshot
+	if err := json.Unmarshal(bz, &s); err != nil {
+		return nil, fmt.Errorf("unmarshal snapshot manifest: %w", err)
+	}
+	s.Dir = dir
+
+	return &s, nil
+}
+
+// snapshotRelayer is implemented by relayers whose home directory can be snapshotted,
+// such as relayer.DockerRelayer.
+type snapshotRelayer interface {
+	ibc.Relayer
+
+	ExportHomeDir(ctx context.Context, w io.Writer) error
+	ImportHomeDir(ctx context.Context, r io.Reader, rewrite dockerutil.RewriteFunc) error
+	RestoreWallet(chainID, keyName, address, mnemonic string)
+}
+
+// Snapshot stops every chain, copies all node and relayer home directories into dir
+// along with the resolved IBC topology, and restarts the chains.
+// Relayers must not be running while the snapshot is taken.
+//
+// Snapshot can only be called after Build.
+// See InterchainSnapshot for the restrictions that apply.
+func (ic *Interchain) Snapshot(ctx context.Context, rep ibc.RelayerExecReporter, dir string) (*InterchainSnapshot, error) {
+	if !ic.built {
+		return nil, fmt.Errorf("cannot snapshot an Interchain before Build")
+	}
+
+	chains, err := ic.snapshotChains()
+	if err != nil {
+		return nil, err
+	}
+	relayers, err := ic.snapshotRelayers()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create snapshot directory: %w", err)
+	}
+
+	s := &InterchainSnapshot{
+		Dir:       dir,
+		CreatedAt: time.Now().UTC(),
+	}
+	if len(chains) > 0 {
+		s.TestName = chains[0].GetNode().TestName
+	}
+
+	// Resolve the topology first, while the chains are still running.
+	links, err := ic.snapshotLinks(ctx, rep)
+	if err != nil {
+		return nil, err
+	}
+	s.Links = links
+
+	for _, c := range chains {
+		cs, err := ic.snapshotChain(ctx, c, dir)
+		if err != nil {
+			return nil, fmt.Errorf("snapshot chain %s: %w", c.Config().ChainID, err)
+		}
+		s.Chains = append(s.Chains, cs)
+	}
+
+	for _, name := range sortedKeys(relayers) {
+		r := relayers[name]
+		rs := RelayerSnapshot{
+			Name:    name,
+			Archive: filepath.Join("relayers", name+".tar"),
+		}
+
+		if err := writeArchive(filepath.Join(dir, rs.Archive), func(w io.Writer) error {
+			return r.ExportHomeDir(ctx, w)
+		}); err != nil {
+			return nil, fmt.Errorf("snapshot relayer %s: %w", name, err)
+		}
+
+		for _, c := range ic.relayerChains()[r] {
+			chainID := c.Config().ChainID
+			w, ok := r.GetWallet(chainID)
+			if !ok {
+				continue
+			}
+			rs.Wallets = append(rs.Wallets, RelayerWalletSnapshot{
+				ChainID:  chainID,
+				KeyName:  w.KeyName(),
+				Address:  w.FormattedAddress(),
+				Mnemonic: w.Mnemonic(),
+			})
+		}
+		sort.Slice(rs.Wallets, func(i, j int) bool { return rs.Wallets[i].ChainID < rs.Wallets[j].ChainID })
+
+		s.Relayers = append(s.Relayers, rs)
+	}
+
+	if err := s.save(); err != nil {
+		return nil, err
+	}
+
+	ic.log.Info("Interchain snapshot saved", zap.String("dir", dir))
+	return s, nil
+}
+
+// snapshotChains returns every chain as a CosmosChain, sorted by chain ID,
+// or an error if any chain cannot be snapshotted.
+func (ic *Interchain) snapshotChains() ([]*cosmos.CosmosChain, error) {
+	chains := make([]*cosmos.CosmosChain, 0, len(ic.chains))
+	for c := range ic.chains {
+		cc, ok := c.(*cosmos.CosmosChain)
+		if !ok {
+			return nil, fmt.Errorf("chain %s of type %T does not support snapshots", c.Config().ChainID, c)
+		}
+		if cc.Config().UsesCometMock() {
+			return nil, fmt.Errorf("chain %s uses CometMock, which does not support snapshots", c.Config().ChainID)
+		}
+		chains = append(chains, cc)
+	}
+	sort.Slice(chains, func(i, j int) bool { return chains[i].Config().ChainID < chains[j].Config().ChainID })
+	return chains, nil
+}
+
+// snapshotRelayers returns every relayer keyed by name,
+// or an error if any relayer cannot be snapshotted.
+func (ic *Interchain) snapshotRelayers() (map[string]snapshotRelayer, error) {
+	relayers := make(map[string]snapshotRelayer, len(ic.relayers))
+	for r, name := range ic.relayers {
+		sr, ok := r.(snapshotRelayer)
+		if !ok {
+			return nil, fmt.Errorf("relayer %s of type %T does not support snapshots", name, r)
+		}
+		relayers[name] = sr
+	}
+	return relayers, nil
+}
+
+// snapshotLinks queries each relayer for the clients, connections and channels of every linked chain.
+func (ic *Interchain) snapshotLinks(ctx context.Context, rep ibc.RelayerExecReporter) ([]LinkSnapshot, error) {
+	var links []LinkSnapshot
+
+	add := func(rp relayerPath, chains [2]ibc.Chain, providerConsumer bool) error {
+		l := LinkSnapshot{
+			Relayer:          ic.relayers[rp.Relayer],
+			Path:             rp.Path,
+			ProviderConsumer: providerConsumer,
+			Clients:          make(map[string]ibc.ClientOutputs, 2),
+			Connections:      make(map[string]ibc.ConnectionOutputs, 2),
+			Channels:         make(map[string][]ibc.ChannelOutput, 2),
+		}
+		for i, c := range chains {
+			chainID := c.Config().ChainID
+			l.ChainIDs[i] = chainID
+
+			clients, err := rp.Relayer.GetClients(ctx, rep, chainID)
+			if err != nil {
+				return fmt.Errorf("get clients on %s: %w", chainID, err)
+			}
+			connections, err := rp.Relayer.GetConnections(ctx, rep, chainID)
+			if err != nil {
+				return fmt.Errorf("get connections on %s: %w", chainID, err)
+			}
+			channels, err := rp.Relayer.GetChannels(ctx, rep, chainID)
+			if err != nil {
+				return fmt.Errorf("get channels on %s: %w", chainID, err)
+			}
+
+			l.Clients[chainID] = clients
+			l.Connections[chainID] = connections
+			l.Channels[chainID] = channels
+		}
+		links = append(links, l)
+		return nil
+	}
+
+	for rp, link := range ic.links {
+		if err := add(rp, link.chains, false); err != nil {
+			return nil, err
+		}
+	}
+	for rp, link := range ic.providerConsumerLinks {
+		if err := add(rp, [2]ibc.Chain{link.provider, link.consumer}, true); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(links, func(i, j int) bool {
+		if links[i].Relayer != links[j].Relayer {
+			return links[i].Relayer < links[j].Relayer
+		}
+		return links[i].Path < links[j].Path
+	})
+	return links, nil
+}
+
+// snapshotChain stops the nodes of c, archives their volumes into dir, and restarts them.
+func (ic *Interchain) snapshotChain(ctx context.Context, c *cosmos.CosmosChain, dir string) (ChainSnapshot, error) {
+	chainID := c.Config().ChainID
+	cs := ChainSnapshot{
+		ChainID: chainID,
+		Name:    c.Config().Name,
+	}
+
+	height, err := c.Height(ctx)
+	if err != nil {
+		return cs, fmt.Errorf("get height: %w", err)
+	}
+	cs.Height = height
+
+	if err := c.StopAllNodes(ctx); err != nil {
+		return cs, fmt.Errorf("stop nodes: %w", err)
+	}
+
+	nodes := c.Nodes()
+	cs.Nodes = make([]NodeSnapshot, len(nodes))
+
+	var eg errgroup.Group
+	for i, n := range nodes {
+		cs.Nodes[i] = NodeSnapshot{
+			Validator:     n.Validator,
+			Index:         n.Index,
+			ContainerName: n.Name(),
+			HostName:      n.HostName(),
+			Archive:       filepath.Join("chains", chainID, fmt.Sprintf("%s-%d.tar", n.NodeType(), n.Index)),
+		}
+		eg.Go(func() error {
+			return writeArchive(filepath.Join(dir, cs.Nodes[i].Archive), func(w io.Writer) error {
+				return n.ExportVolume(ctx, w)
+			})
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		// Restart the nodes anyway, so a failed snapshot does not leave the chain halted.
+		err = fmt.Errorf("export volumes: %w", err)
+		if restartErr := c.StartFromVolumes(ctx); restartErr != nil {
+			err = errors.Join(err, fmt.Errorf("restart nodes: %w", restartErr))
+		}
+		return cs, err
+	}
+
+	if err := c.StartFromVolumes(ctx); err != nil {
+		return cs, fmt.Errorf("restart nodes: %w", err)
+	}
+
+	return cs, nil
+}
+
+// restoreSnapshot populates the already initialized chains and relayers from s
+// and starts the chains, in place of the genesis and path creation steps of Build.
+func (ic *Interchain) restoreSnapshot(ctx context.Context, s *InterchainSnapshot) error {
+	chains, err := ic.snapshotChains()
+	if err != nil {
+		return err
+	}
+	relayers, err := ic.snapshotRelayers()
+	if err != nil {
+		return err
+	}
+
+	// Node names embed the test name, so references to the old nodes are rewritten.
+	var renames []string
+	for _, c := range chains {
+		chainID := c.Config().ChainID
+		cs, ok := s.chain(chainID)
+		if !ok {
+			return fmt.Errorf("snapshot in %s has no chain with ID %s", s.Dir, chainID)
+		}
+		nodes := c.Nodes()
+		if len(nodes) != len(cs.Nodes) {
+			return fmt.Errorf("chain %s has %d nodes but its snapshot has %d", chainID, len(nodes), len(cs.Nodes))
+		}
+		for i, n := range nodes {
+			ns := cs.Nodes[i]
+			if n.Validator != ns.Validator || n.Index != ns.Index {
+				return fmt.Errorf("chain %s node %s does not match snapshot node %s", chainID, n.Name(), ns.ContainerName)
+			}
+			renames = append(renames, ns.ContainerName, n.Name(), ns.HostName, n.HostName())
+		}
+	}
+	rewrite := snapshotRewriter(renames)
+
+	var eg errgroup.Group
+	for _, c := range chains {
+		cs, _ := s.chain(c.Config().ChainID)
+		for i, n := range c.Nodes() {
+			archive := filepath.Join(s.Dir, cs.Nodes[i].Archive)
+			eg.Go(func() error {
+				return readArchive(archive, func(r io.Reader) error {
+					return n.ImportVolume(ctx, r, rewrite)
+				})
+			})
+		}
+	}
+	if err := eg.Wait(); err != nil {
+		return fmt.Errorf("failed to restore chain volumes: %w", err)
+	}
+
+	eg = errgroup.Group{}
+	for _, c := range chains {
+		eg.Go(func() error {
+			if err := c.StartFromVolumes(ctx); err != nil {
+				return fmt.Errorf("failed to start restored chain %s: %w", c.Config().ChainID, err)
+			}
+			return nil
+		})
+	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	for _, name := range sortedKeys(relayers) {
+		r := relayers[name]
+		rs, ok := s.relayer(name)
+		if !ok {
+			return fmt.Errorf("snapshot in %s has no relayer named %s", s.Dir, name)
+		}
+		if err := readArchive(filepath.Join(s.Dir, rs.Archive), func(rd io.Reader) error {
+			return r.ImportHomeDir(ctx, rd, rewrite)
+		}); err != nil {
+			return fmt.Errorf("failed to restore relayer %s: %w", name, err)
+		}
+		for _, w := range rs.Wallets {
+			r.RestoreWallet(w.ChainID, w.KeyName, w.Address, w.Mnemonic)
+		}
+	}
+
+	ic.log.Info("Interchain restored from snapshot", zap.String("dir", s.Dir))
+	return nil
+}
+
+// snapshotRewriter returns a dockerutil.RewriteFunc replacing each old/new pair in renames
+// within text configuration files. Longer names are replaced first,
+// so that a name which is a prefix of another is not partially rewritten.
+func snapshotRewriter(renames []string) dockerutil.RewriteFunc {
+	type pair struct{ old, new string }
+	pairs := make([]pair, 0, len(renames)/2)
+	for i := 0; i+1 < len(renames); i += 2 {
+		if renames[i] == renames[i+1] {
+			continue
+		}
+		pairs = append(pairs, pair{renames[i], renames[i+1]})
+	}
+	sort.SliceStable(pairs, func(i, j int) bool { return len(pairs[i].old) > len(pairs[j].old) })
+
+	args := make([]string, 0, len(pairs)*2)
+	for _, p := range pairs {
+		args = append(args, p.old, p.new)
+	}
+	replacer := strings.NewReplacer(args...)
+
+	return func(relPath string, content []byte) []byte {
+		switch filepath.Ext(relPath) {
+		case ".toml", ".json", ".yaml", ".yml", ".config":
+		default:
+			return content
+		}
+		if len(args) == 0 {
+			return content
+		}
+		return []byte(replacer.Replace(string(content)))
+	}
+}
+
+// writeArchive creates the file at path, including parent directories, and passes it to write.
+func writeArchive(path string, write func(io.Writer) error) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// readArchive opens the file at path and passes it to read.
+func readArchive(path string, read func(io.Reader) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return read(f)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
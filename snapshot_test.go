@@ -0,0 +1,80 @@
+package interchaintest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+)
+
+func TestInterchainSnapshot_SaveLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	s := &InterchainSnapshot{
+		Dir:       dir,
+		TestName:  "TestFoo",
+		CreatedAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Chains: []ChainSnapshot{{
+			ChainID: "gaia-1",
+			Name:    "gaia",
+			Height:  42,
+			Nodes: []NodeSnapshot{{
+				Validator:     true,
+				Index:         0,
+				ContainerName: "gaia-1-val-0-TestFoo",
+				HostName:      "gaia-1-val-0-TestFoo",
+				Archive:       filepath.Join("chains", "gaia-1", "val-0.tar"),
+			}},
+		}},
+		Relayers: []RelayerSnapshot{{
+			Name:    "r",
+			Archive: filepath.Join("relayers", "r.tar"),
+			Wallets: []RelayerWalletSnapshot{{ChainID: "gaia-1", KeyName: "k", Address: "cosmos1abc", Mnemonic: "m"}},
+		}},
+		Links: []LinkSnapshot{{
+			Relayer:  "r",
+			Path:     "p",
+			ChainIDs: [2]string{"gaia-1", "osmosis-1"},
+			Channels: map[string][]ibc.ChannelOutput{
+				"gaia-1": {{ChannelID: "channel-0", PortID: "transfer"}},
+			},
+		}},
+	}
+	require.NoError(t, s.save())
+
+	got, err := LoadInterchainSnapshot(dir)
+	require.NoError(t, err)
+	require.Equal(t, s, got)
+
+	l, ok := got.Link("r", "p")
+	require.True(t, ok)
+	require.Equal(t, "channel-0", l.Channels["gaia-1"][0].ChannelID)
+
+	_, ok = got.Link("r", "other")
+	require.False(t, ok)
+
+	_, err = LoadInterchainSnapshot(filepath.Join(dir, "missing"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestSnapshotRewriter(t *testing.T) {
+	rewrite := snapshotRewriter([]string{
+		"gaia-1-val-0-TestOld", "gaia-1-val-0-TestNew",
+		"gaia-1-val-0-TestOld_Sub", "gaia-1-val-0-TestNew_Sub",
+		"unchanged", "unchanged",
+	})
+
+	const cfg = `persistent_peers = "id@gaia-1-val-0-TestOld_Sub:26656,id@gaia-1-val-0-TestOld:26656"`
+
+	require.Equal(t,
+		`persistent_peers = "id@gaia-1-val-0-TestNew_Sub:26656,id@gaia-1-val-0-TestNew:26656"`,
+		string(rewrite("config/config.toml", []byte(cfg))),
+	)
+
+	// Non-config files, such as databases, are left untouched.
+	require.Equal(t, cfg, string(rewrite("data/application.db/000001.log", []byte(cfg))))
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/moby/moby/client"
+	"go.uber.org/zap"
 
 	"github.com/strangelove-ventures/interchaintest/v8/dockerutil"
 	"github.com/strangelove-ventures/interchaintest/v8/ibc"
@@ -38,6 +39,13 @@ func DockerSetup(t dockerutil.DockerSetupTestingT) (*client.Client, string) {
 	return dockerutil.DockerSetup(t)
 }
 
+// NewNetworkChaos returns a helper to partition and degrade the network between containers
+// attached to the Docker network from DockerSetup. Faults are scoped to t and cleaned up with it.
+func NewNetworkChaos(t dockerutil.DockerSetupTestingT, log *zap.Logger, cli *client.Client, networkID string) *dockerutil.NetworkChaos {
+	t.Helper()
+	return dockerutil.NewNetworkChaos(log, cli, networkID, t.Name())
+}
+
 // startup both chains
 // creates wallets in the relayer for src and dst chain
 // funds relayer src and dst wallets on respective chain in genesis
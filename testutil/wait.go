@@ -71,20 +71,24 @@ func NodesInSync(ctx context.Context, chain ChainHeighter, nodes []ChainHeighter
 	return nil
 }
 
+// inSyncPollInterval is the delay between checks of WaitForInSync,
+// which spares nodes that are down or restoring a state sync snapshot from a flood of queries.
+const inSyncPollInterval = 100 * time.Millisecond
+
 // WaitForInSync blocks until all nodes have heights greater than or equal to the chain height.
+// Nodes failing to report their height, e.g. while they start or restore a state sync snapshot, are not in sync yet.
 func WaitForInSync(ctx context.Context, chain ChainHeighter, nodes ...ChainHeighter) error {
 	if len(nodes) == 0 {
 		panic("missing nodes")
 	}
 	for {
+		if err := NodesInSync(ctx, chain, nodes); err == nil {
+			return nil
+		}
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
-		default:
-			if err := NodesInSync(ctx, chain, nodes); err != nil {
-				continue
-			}
-			return nil
+		case <-time.After(inSyncPollInterval):
 		}
 	}
 }
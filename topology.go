@@ -0,0 +1,479 @@
+package interchaintest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/moby/moby/client"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+
+	sdkmath "cosmossdk.io/math"
+
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+	"github.com/strangelove-ventures/interchaintest/v8/relayer"
+)
+
+// Topology is a declarative description of an Interchain:
+// its chains, relayers, links, provider/consumer links and additional genesis wallets.
+// Use LoadTopology or ParseTopology to read one from a YAML or JSON file,
+// and (*Topology).NewInterchain to turn it into an Interchain that is ready to Build.
+//
+// A minimal topology looks like:
+//
+//	chains:
+//	  - name: gaia
+//	    version: v15.1.0
+//	    chain-id: gaia-1
+//	  - name: osmosis
+//	    version: v25.0.0
+//	    chain-id: osmosis-1
+//	relayers:
+//	  - name: rly
+//	    implementation: cosmos-rly
+//	links:
+//	  - chain1: gaia-1
+//	    chain2: osmosis-1
+//	    relayer: rly
+//	    path: gaia-osmo
+//
+// Links and genesis wallets refer to chains by chain ID, and to relayers by name.
+type Topology struct {
+	Chains                []TopologyChain                `yaml:"chains"`
+	Relayers              []TopologyRelayer              `yaml:"relayers"`
+	Links                 []TopologyLink                 `yaml:"links"`
+	ProviderConsumerLinks []TopologyProviderConsumerLink `yaml:"provider-consumer-links"`
+
+	// source is the name of the file the topology was parsed from, used in errors.
+	source string
+
+	// configs holds the resolved config of each chain, in the same order as Chains.
+	configs []ibc.ChainConfig
+}
+
+// TopologyChain describes a single chain of a Topology.
+// Fields other than ChainID and GenesisWallets have the same meaning as in ChainSpec.
+type TopologyChain struct {
+	// Name of the built-in chain config to use as a basis for this chain.
+	Name string `yaml:"name"`
+
+	ChainName string `yaml:"chain-name"`
+
+	// ChainID must be set and must be unique within the topology.
+	ChainID string `yaml:"chain-id"`
+
+	Version string `yaml:"version"`
+
+	NumValidators *int `yaml:"num-validators"`
+	NumFullNodes  *int `yaml:"num-full-nodes"`
+
+	// Config overrides fields of the built-in config.
+	Config ibc.ChainConfig `yaml:"config"`
+
+	// GenesisWallets are funded in genesis, in addition to the faucet and relayer wallets.
+	GenesisWallets []TopologyWallet `yaml:"genesis-wallets"`
+}
+
+// TopologyWallet is a genesis balance.
+type TopologyWallet struct {
+	Address string `yaml:"address"`
+
+	// Denom defaults to the denom of the chain.
+	Denom string `yaml:"denom"`
+
+	// Amount is an integer in the smallest unit of Denom.
+	Amount string `yaml:"amount"`
+}
+
+// TopologyRelayer describes a single relayer of a Topology.
+type TopologyRelayer struct {
+	// Name must be unique within the topology.
+	Name string `yaml:"name"`
+
+	// Implementation is one of cosmos-rly, hermes or hyperspace.
+	Implementation string `yaml:"implementation"`
+
+	// Image optionally overrides the default docker image of the implementation.
+	Image *ibc.DockerImage `yaml:"image"`
+
+	// StartupFlags are passed to the relayer when it is started.
+	StartupFlags []string `yaml:"startup-flags"`
+}
+
+// TopologyLink describes an IBC link between two chains, as added by (*Interchain).AddLink.
+type TopologyLink struct {
+	Chain1  string `yaml:"chain1"`
+	Chain2  string `yaml:"chain2"`
+	Relayer string `yaml:"relayer"`
+	Path    string `yaml:"path"`
+
+	// Client and Channel default to ibc.DefaultClientOpts and ibc.DefaultChannelOpts.
+	Client  *TopologyClientOptions  `yaml:"client"`
+	Channel *TopologyChannelOptions `yaml:"channel"`
+}
+
+// TopologyProviderConsumerLink describes an ICS link, as added by (*Interchain).AddProviderConsumerLink.
+type TopologyProviderConsumerLink struct {
+	Provider string `yaml:"provider"`
+	Consumer string `yaml:"consumer"`
+	Relayer  string `yaml:"relayer"`
+	Path     string `yaml:"path"`
+}
+
+// TopologyClientOptions mirrors ibc.CreateClientOptions.
+type TopologyClientOptions struct {
+	TrustingPeriod           string `yaml:"trusting-period"`
+	TrustingPeriodPercentage int64  `yaml:"trusting-period-percentage"`
+	MaxClockDrift            string `yaml:"max-clock-drift"`
+	Override                 bool   `yaml:"override"`
+}
+
+// TopologyChannelOptions mirrors ibc.CreateChannelOptions.
+type TopologyChannelOptions struct {
+	SourcePort string `yaml:"source-port"`
+	DestPort   string `yaml:"dest-port"`
+
+	// Order is either ordered or unordered.
+	Order string `yaml:"order"`
+
+	Version string `yaml:"version"`
+}
+
+// TopologyError reports a problem with a single key of a topology file.
+type TopologyError struct {
+	// File is the name of the topology file.
+	File string
+
+	// Key is the path of the offending key, e.g. links[1].chain2.
+	Key string
+
+	Err error
+}
+
+func (e *TopologyError) Error() string {
+	return fmt.Sprintf("%s: %s: %v", e.File, e.Key, e.Err)
+}
+
+func (e *TopologyError) Unwrap() error {
+	return e.Err
+}
+
+// LoadTopology reads and validates the YAML or JSON topology file at path.
+func LoadTopology(path string) (*Topology, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read topology: %w", err)
+	}
+	return ParseTopology(path, data)
+}
+
+// ParseTopology parses and validates a YAML or JSON topology.
+// source names the topology in errors, typically the path of the file it was read from.
+//
+// Unknown keys are rejected. All validation errors are returned together,
+// each as a *TopologyError.
+func ParseTopology(source string, data []byte) (*Topology, error) {
+	t := &Topology{source: source}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(t); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%s: %w", source, err)
+	}
+
+	if err := t.validate(); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// validate checks every entry of t and resolves the chain configs.
+func (t *Topology) validate() error {
+	var errs []error
+	fail := func(key string, format string, args ...any) {
+		errs = append(errs, &TopologyError{File: t.source, Key: key, Err: fmt.Errorf(format, args...)})
+	}
+
+	if len(t.Chains) == 0 {
+		fail("chains", "at least one chain is required")
+	}
+
+	chainIDs := make(map[string]bool, len(t.Chains))
+	t.configs = make([]ibc.ChainConfig, len(t.Chains))
+	for i, c := range t.Chains {
+		key := fmt.Sprintf("chains[%d]", i)
+
+		switch {
+		case c.ChainID == "":
+			fail(key+".chain-id", "must be set")
+		case chainIDs[c.ChainID]:
+			fail(key+".chain-id", "duplicate chain ID %q", c.ChainID)
+		}
+		chainIDs[c.ChainID] = true
+
+		spec := c.spec()
+		cfg, err := spec.Config(zap.NewNop())
+		if err != nil {
+			fail(key, "%w", err)
+			continue
+		}
+		t.configs[i] = *cfg
+
+		for j, w := range c.GenesisWallets {
+			wkey := fmt.Sprintf("%s.genesis-wallets[%d]", key, j)
+			if w.Address == "" {
+				fail(wkey+".address", "must be set")
+			}
+			if _, ok := sdkmath.NewIntFromString(w.Amount); !ok {
+				fail(wkey+".amount", "invalid integer amount %q", w.Amount)
+			}
+		}
+	}
+
+	relayerNames := make(map[string]bool, len(t.Relayers))
+	for i, r := range t.Relayers {
+		key := fmt.Sprintf("relayers[%d]", i)
+
+		switch {
+		case r.Name == "":
+			fail(key+".name", "must be set")
+		case relayerNames[r.Name]:
+			fail(key+".name", "duplicate relayer name %q", r.Name)
+		}
+		relayerNames[r.Name] = true
+
+		if _, err := parseRelayerImplementation(r.Implementation); err != nil {
+			fail(key+".implementation", "%w", err)
+		}
+		if r.Image != nil {
+			if err := r.Image.Validate(); err != nil {
+				fail(key+".image", "%w", err)
+			}
+		}
+	}
+
+	type relayerPathKey struct{ relayer, path string }
+	paths := make(map[relayerPathKey]bool, len(t.Links)+len(t.ProviderConsumerLinks))
+	checkPath := func(key, relayerName, path string) {
+		switch {
+		case relayerName == "":
+			fail(key+".relayer", "must be set")
+		case !relayerNames[relayerName]:
+			fail(key+".relayer", "unknown relayer %q", relayerName)
+		}
+
+		k := relayerPathKey{relayerName, path}
+		switch {
+		case path == "":
+			fail(key+".path", "must be set")
+		case paths[k]:
+			fail(key+".path", "relayer %q already has a path named %q", relayerName, path)
+		}
+		paths[k] = true
+	}
+	checkChain := func(key, chainID string) {
+		switch {
+		case chainID == "":
+			fail(key, "must be set")
+		case !chainIDs[chainID]:
+			fail(key, "unknown chain ID %q", chainID)
+		}
+	}
+
+	for i, l := range t.Links {
+		key := fmt.Sprintf("links[%d]", i)
+		checkChain(key+".chain1", l.Chain1)
+		checkChain(key+".chain2", l.Chain2)
+		if l.Chain1 != "" && l.Chain1 == l.Chain2 {
+			fail(key+".chain2", "chains must be different (both were %q)", l.Chain1)
+		}
+		checkPath(key, l.Relayer, l.Path)
+
+		if l.Client != nil {
+			if err := l.Client.options().Validate(); err != nil {
+				fail(key+".client", "%w", err)
+			}
+		}
+		if l.Channel != nil {
+			opts, err := l.Channel.options()
+			if err == nil {
+				err = opts.Validate()
+			}
+			if err != nil {
+				fail(key+".channel", "%w", err)
+			}
+		}
+	}
+
+	for i, l := range t.ProviderConsumerLinks {
+		key := fmt.Sprintf("provider-consumer-links[%d]", i)
+		checkChain(key+".provider", l.Provider)
+		checkChain(key+".consumer", l.Consumer)
+		if l.Provider != "" && l.Provider == l.Consumer {
+			fail(key+".consumer", "chains must be different (both were %q)", l.Provider)
+		}
+		checkPath(key, l.Relayer, l.Path)
+	}
+
+	return errors.Join(errs...)
+}
+
+// TopologyInterchain is an Interchain created from a Topology,
+// along with its chains and relayers so that tests can refer to them.
+type TopologyInterchain struct {
+	*Interchain
+
+	// Chains keyed by chain ID.
+	Chains map[string]ibc.Chain
+
+	// Relayers keyed by name.
+	Relayers map[string]ibc.Relayer
+}
+
+// NewInterchain creates the chains and relayers described by t and adds them,
+// their links and genesis wallets to a new Interchain.
+// The returned Interchain is ready to Build with the same client and network,
+// and collects errors instead of panicking, as with (*Interchain).CollectErrors.
+func (t *Topology) NewInterchain(testName TestName, log *zap.Logger, cli *client.Client, networkID string) (*TopologyInterchain, error) {
+	ic := NewInterchain().WithLog(log).CollectErrors()
+	ti := &TopologyInterchain{
+		Interchain: ic,
+		Chains:     make(map[string]ibc.Chain, len(t.Chains)),
+		Relayers:   make(map[string]ibc.Relayer, len(t.Relayers)),
+	}
+
+	for i, c := range t.Chains {
+		chain, err := buildChain(log, testName.Name(), t.configs[i], c.NumValidators, c.NumFullNodes)
+		if err != nil {
+			return nil, &TopologyError{File: t.source, Key: fmt.Sprintf("chains[%d]", i), Err: err}
+		}
+
+		wallets := make([]ibc.WalletAmount, len(c.GenesisWallets))
+		for j, w := range c.GenesisWallets {
+			// Already validated.
+			amount, _ := sdkmath.NewIntFromString(w.Amount)
+			denom := w.Denom
+			if denom == "" {
+				denom = t.configs[i].Denom
+			}
+			wallets[j] = ibc.WalletAmount{Address: w.Address, Denom: denom, Amount: amount}
+		}
+
+		ic.AddChain(chain, wallets...)
+		ti.Chains[c.ChainID] = chain
+	}
+
+	for _, r := range t.Relayers {
+		// Already validated.
+		impl, _ := parseRelayerImplementation(r.Implementation)
+
+		var opts []relayer.RelayerOpt
+		if r.Image != nil {
+			opts = append(opts, relayer.DockerImage(r.Image))
+		}
+		if len(r.StartupFlags) > 0 {
+			opts = append(opts, relayer.StartupFlags(r.StartupFlags...))
+		}
+
+		rel := NewBuiltinRelayerFactory(impl, log, opts...).Build(testName, cli, networkID)
+		ic.AddRelayer(rel, r.Name)
+		ti.Relayers[r.Name] = rel
+	}
+
+	for _, l := range t.Links {
+		link := InterchainLink{
+			Chain1:  ti.Chains[l.Chain1],
+			Chain2:  ti.Chains[l.Chain2],
+			Relayer: ti.Relayers[l.Relayer],
+			Path:    l.Path,
+		}
+		if l.Client != nil {
+			link.CreateClientOpts = l.Client.options()
+		}
+		if l.Channel != nil {
+			// Already validated.
+			link.CreateChannelOpts, _ = l.Channel.options()
+		}
+		ic.AddLink(link)
+	}
+
+	for _, l := range t.ProviderConsumerLinks {
+		ic.AddProviderConsumerLink(ProviderConsumerLink{
+			Provider: ti.Chains[l.Provider],
+			Consumer: ti.Chains[l.Consumer],
+			Relayer:  ti.Relayers[l.Relayer],
+			Path:     l.Path,
+		})
+	}
+
+	if err := ic.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", t.source, err)
+	}
+
+	return ti, nil
+}
+
+// spec returns the ChainSpec equivalent to c.
+func (c TopologyChain) spec() *ChainSpec {
+	cfg := c.Config
+	cfg.ChainID = c.ChainID
+	return &ChainSpec{
+		Name:          c.Name,
+		ChainName:     c.ChainName,
+		Version:       c.Version,
+		ChainConfig:   cfg,
+		NumValidators: c.NumValidators,
+		NumFullNodes:  c.NumFullNodes,
+	}
+}
+
+func (o TopologyClientOptions) options() ibc.CreateClientOptions {
+	return ibc.CreateClientOptions{
+		TrustingPeriod:           o.TrustingPeriod,
+		TrustingPeriodPercentage: o.TrustingPeriodPercentage,
+		MaxClockDrift:            o.MaxClockDrift,
+		Override:                 o.Override,
+	}
+}
+
+func (o TopologyChannelOptions) options() (ibc.CreateChannelOptions, error) {
+	opts := ibc.DefaultChannelOpts()
+	if o.SourcePort != "" {
+		opts.SourcePortName = o.SourcePort
+	}
+	if o.DestPort != "" {
+		opts.DestPortName = o.DestPort
+	}
+	if o.Version != "" {
+		opts.Version = o.Version
+	}
+	switch strings.ToLower(o.Order) {
+	case "":
+	case ibc.Ordered.String():
+		opts.Order = ibc.Ordered
+	case ibc.Unordered.String():
+		opts.Order = ibc.Unordered
+	default:
+		return opts, fmt.Errorf("invalid order %q, must be %s or %s", o.Order, ibc.Ordered, ibc.Unordered)
+	}
+	return opts, nil
+}
+
+// parseRelayerImplementation returns the relayer implementation with the given name.
+func parseRelayerImplementation(name string) (ibc.RelayerImplementation, error) {
+	switch strings.ToLower(name) {
+	case "cosmos-rly", "rly":
+		return ibc.CosmosRly, nil
+	case "hermes":
+		return ibc.Hermes, nil
+	case "hyperspace":
+		return ibc.Hyperspace, nil
+	default:
+		return 0, fmt.Errorf("unknown relayer implementation %q, must be cosmos-rly, hermes or hyperspace", name)
+	}
+}
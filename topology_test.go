@@ -0,0 +1,127 @@
+package interchaintest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/strangelove-ventures/interchaintest/v8/ibc"
+)
+
+func TestLoadTopology(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "topology.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+chains:
+  - name: gaia
+    version: v15.1.0
+    chain-id: gaia-1
+    num-validators: 1
+    num-full-nodes: 0
+    genesis-wallets:
+      - address: cosmos1hj5fveer5cjtn4wd6wstzugjfdxzl0xpxvjjvr
+        amount: "1000"
+  - name: osmosis
+    version: v25.0.0
+    chain-id: osmosis-1
+    config:
+      gas-prices: 0.1uosmo
+relayers:
+  - name: rly
+    implementation: cosmos-rly
+links:
+  - chain1: gaia-1
+    chain2: osmosis-1
+    relayer: rly
+    path: gaia-osmo
+    channel:
+      order: ordered
+`), 0o644))
+
+	topo, err := LoadTopology(path)
+	require.NoError(t, err)
+
+	require.Len(t, topo.configs, 2)
+	require.Equal(t, "gaia-1", topo.configs[0].ChainID)
+	require.Equal(t, "v15.1.0", topo.configs[0].Images[0].Version)
+	require.Equal(t, "osmosis-1", topo.configs[1].ChainID)
+	require.Equal(t, "0.1uosmo", topo.configs[1].GasPrices)
+
+	opts, err := topo.Links[0].Channel.options()
+	require.NoError(t, err)
+	require.Equal(t, ibc.Ordered, opts.Order)
+	require.Equal(t, "transfer", opts.SourcePortName)
+}
+
+func TestParseTopology_JSON(t *testing.T) {
+	topo, err := ParseTopology("topology.json", []byte(`{
+  "chains": [{"name": "gaia", "version": "v15.1.0", "chain-id": "gaia-1"}]
+}`))
+	require.NoError(t, err)
+	require.Equal(t, "gaia-1", topo.configs[0].ChainID)
+}
+
+func TestParseTopology_Errors(t *testing.T) {
+	t.Run("unknown key", func(t *testing.T) {
+		_, err := ParseTopology("topology.yaml", []byte(`
+chains:
+  - name: gaia
+    chainid: gaia-1
+`))
+		require.ErrorContains(t, err, "topology.yaml: ")
+		require.ErrorContains(t, err, "line 4: field chainid not found")
+	})
+
+	t.Run("invalid references", func(t *testing.T) {
+		_, err := ParseTopology("topology.yaml", []byte(`
+chains:
+  - name: gaia
+    version: v15.1.0
+    chain-id: gaia-1
+  - name: gaia
+    version: v15.1.0
+    chain-id: gaia-1
+    genesis-wallets:
+      - address: cosmos1abc
+        amount: lots
+relayers:
+  - name: rly
+    implementation: go-relayer
+links:
+  - chain1: gaia-1
+    chain2: osmosis-1
+    relayer: hermes
+    path: p
+    channel:
+      order: sorted
+provider-consumer-links:
+  - provider: gaia-1
+    consumer: gaia-1
+    relayer: rly
+`))
+		require.Error(t, err)
+
+		var topoErr *TopologyError
+		require.ErrorAs(t, err, &topoErr)
+		require.Equal(t, "topology.yaml", topoErr.File)
+
+		for _, msg := range []string{
+			`topology.yaml: chains[1].chain-id: duplicate chain ID "gaia-1"`,
+			`topology.yaml: chains[1].genesis-wallets[0].amount: invalid integer amount "lots"`,
+			`topology.yaml: relayers[0].implementation: unknown relayer implementation "go-relayer"`,
+			`topology.yaml: links[0].chain2: unknown chain ID "osmosis-1"`,
+			`topology.yaml: links[0].relayer: unknown relayer "hermes"`,
+			`topology.yaml: links[0].channel: invalid order "sorted"`,
+			`topology.yaml: provider-consumer-links[0].consumer: chains must be different (both were "gaia-1")`,
+			`topology.yaml: provider-consumer-links[0].path: must be set`,
+		} {
+			require.ErrorContains(t, err, msg)
+		}
+	})
+
+	t.Run("no chains", func(t *testing.T) {
+		_, err := ParseTopology("empty.yaml", nil)
+		require.EqualError(t, err, "empty.yaml: chains: at least one chain is required")
+	})
+}